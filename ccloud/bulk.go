@@ -0,0 +1,37 @@
+package ccloud
+
+import (
+	"context"
+	"errors"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+)
+
+// planned treats a call recorded by a dry run as done, so that composite
+// operations carry on and the plan holds every call they would make.
+func planned(err error) error {
+	if errors.Is(err, client.ErrDryRun) {
+		return nil
+	}
+	return err
+}
+
+// BulkCreateRoleBindings creates role bindings concurrently, see common.Bulk.
+func (c *ConfluentClient) BulkCreateRoleBindings(ctx context.Context, reqs []*RoleBindingCreateReq, opts ...common.BulkOption) *common.BulkReport[*RoleBindingCreateReq, *RoleBinding] {
+	return common.Bulk(ctx, reqs, c.CreateRoleBindingWithContext, opts...)
+}
+
+// BulkDeleteApiKeys deletes API keys by id concurrently, see common.Bulk.
+func (c *ConfluentClient) BulkDeleteApiKeys(ctx context.Context, apiKeyIds []string, opts ...common.BulkOption) *common.BulkReport[string, struct{}] {
+	return common.BulkDo(ctx, apiKeyIds, c.DeleteApiKeyWithContext, opts...)
+}
+
+// createRoleBindings is BulkCreateRoleBindings for composite operations,
+// see planned.
+func (c *ConfluentClient) createRoleBindings(ctx context.Context, reqs []*RoleBindingCreateReq, opts ...common.BulkOption) *common.BulkReport[*RoleBindingCreateReq, *RoleBinding] {
+	return common.Bulk(ctx, reqs, func(ctx context.Context, req *RoleBindingCreateReq) (*RoleBinding, error) {
+		rb, err := c.CreateRoleBindingWithContext(ctx, req)
+		return rb, planned(err)
+	}, opts...)
+}
@@ -0,0 +1,47 @@
+package ccloud_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
+	"github.com/electric-saw/ccloud-client-go/ccloud/cluster"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkTopics(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	envId := srv.AddEnvironment("dev")
+	clusterId, err := srv.AddKafkaCluster(envId, "orders")
+	require.NoError(t, err)
+
+	clusterClient, err := srv.ClusterClient(clusterId)
+	require.NoError(t, err)
+
+	var reqs []*cluster.TopicCreateReq
+	for i := range 20 {
+		reqs = append(reqs, &cluster.TopicCreateReq{TopicName: fmt.Sprintf("topic-%02d", i)})
+	}
+	reqs = append(reqs, &cluster.TopicCreateReq{TopicName: "topic-00"})
+
+	report := clusterClient.BulkCreateTopics(context.Background(), reqs, common.WithConcurrency(5))
+	assert.Len(t, report.Succeeded(), 20)
+	require.Len(t, report.Failed(), 1)
+	assert.Equal(t, 20, report.Failed()[0].Index)
+	assert.ErrorIs(t, report.Err(), common.ErrConflict)
+	assert.Equal(t, "topic-05", report.Results[5].Value.TopicName)
+
+	deleted := clusterClient.BulkDeleteTopics(context.Background(), []string{"topic-01", "missing", "topic-02"}, common.WithConcurrency(1), common.WithFailFast())
+	assert.NoError(t, deleted.Results[0].Err)
+	assert.ErrorIs(t, deleted.Results[1].Err, common.ErrNotFound)
+	assert.True(t, deleted.Results[2].Skipped())
+
+	topics, err := clusterClient.ListAllTopics(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, topics, 19)
+}
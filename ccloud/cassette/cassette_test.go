@@ -0,0 +1,122 @@
+package cassette_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/cassette"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Internal", "dropped")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/iam/v2/api-keys":
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = io.WriteString(w, `{"id":"ABCKEY","spec":{"display_name":"ci","secret":"live-secret"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/iam/v2/api-keys/ABCKEY":
+			_, _ = io.WriteString(w, `{"id":"ABCKEY","spec":{"display_name":"ci"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	filename := filepath.Join(t.TempDir(), "cassettes", "api_key.json")
+
+	recorder, err := cassette.New(filename, cassette.ModeRecord, cassette.WithScrubber(func(i *cassette.Interaction) {
+		i.Request.Url = strings.Replace(i.Request.Url, ts.URL, "https://api.confluent.cloud", 1)
+	}))
+	require.NoError(t, err)
+
+	c := ccloud.NewClient(recorder.ClientOptions()...).WithBaseUrl(ts.URL).WithAuth(ccloud.NewBasicAuth("key", "basic-secret"))
+	created, err := c.CreateApiKey(&ccloud.ApiKeyCreateReq{DisplayName: "ci"})
+	require.NoError(t, err)
+	assert.Equal(t, "live-secret", created.Spec.Secret, "the caller sees the real response while recording")
+	_, err = c.GetApiKey("ABCKEY")
+	require.NoError(t, err)
+	require.NoError(t, recorder.Stop())
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "live-secret")
+	assert.NotContains(t, string(data), "basic-secret")
+	assert.NotContains(t, string(data), "X-Internal")
+	assert.Contains(t, string(data), "https://api.confluent.cloud/iam/v2/api-keys")
+
+	// Replaying needs neither the server nor credentials.
+	ts.Close()
+
+	replayer, err := cassette.New(filename, cassette.ModeReplay)
+	require.NoError(t, err)
+
+	c = ccloud.NewClient(replayer.ClientOptions()...)
+	created, err = c.CreateApiKeyWithContext(context.Background(), &ccloud.ApiKeyCreateReq{DisplayName: "ci"})
+	require.NoError(t, err)
+	assert.Equal(t, "ABCKEY", created.Id)
+	assert.Equal(t, "[REDACTED]", created.Spec.Secret)
+
+	apiKey, err := c.GetApiKey("ABCKEY")
+	require.NoError(t, err)
+	assert.Equal(t, "ci", apiKey.Spec.DisplayName)
+
+	// Each interaction is replayed once.
+	_, err = c.GetApiKey("ABCKEY")
+	assert.ErrorContains(t, err, "no recorded interaction for GET /iam/v2/api-keys/ABCKEY")
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	_, err := cassette.New(filepath.Join(t.TempDir(), "missing.json"), cassette.ModeReplay)
+	assert.ErrorIs(t, err, cassette.ErrNoCassette)
+	assert.ErrorContains(t, err, cassette.ModeEnv+"=record")
+}
+
+func TestDefaultMatcherIgnoresHostAndParamOrder(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:1234/cmk/v2/clusters?page_size=1&environment=env-123", nil)
+
+	assert.True(t, cassette.DefaultMatcher(req, &cassette.Request{Method: http.MethodGet, Url: "https://api.confluent.cloud/cmk/v2/clusters?environment=env-123&page_size=1"}))
+	assert.False(t, cassette.DefaultMatcher(req, &cassette.Request{Method: http.MethodGet, Url: "https://api.confluent.cloud/cmk/v2/clusters?environment=env-456&page_size=1"}))
+	assert.False(t, cassette.DefaultMatcher(req, &cassette.Request{Method: http.MethodDelete, Url: "https://api.confluent.cloud/cmk/v2/clusters?environment=env-123&page_size=1"}))
+}
+
+func TestDefaultMatcherComparesBody(t *testing.T) {
+	recorded := &cassette.Request{
+		Method: http.MethodPost,
+		Url:    "https://api.confluent.cloud/iam/v2/api-keys",
+		Body:   json.RawMessage(`{"spec":{"display_name":"ci","owner":{"id":"sa-1"}}}`),
+	}
+	newRequest := func(body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:1234/iam/v2/api-keys", strings.NewReader(body))
+		return req
+	}
+
+	req := newRequest(`{"spec": {"owner": {"id": "sa-1"}, "display_name": "ci"}}`)
+	assert.True(t, cassette.DefaultMatcher(req, recorded), "keys and spacing do not matter")
+	assert.True(t, cassette.DefaultMatcher(req, recorded), "the body can be read again")
+
+	assert.False(t, cassette.DefaultMatcher(newRequest(`{"spec":{"display_name":"ci","owner":{"id":"sa-2"}}}`), recorded))
+	assert.False(t, cassette.DefaultMatcher(newRequest(""), recorded))
+	assert.True(t, cassette.MatchIgnoringBody(newRequest(`{"spec":{"display_name":"ci","owner":{"id":"sa-2"}}}`), recorded))
+}
+
+func TestDefaultMatcherRedactsSecrets(t *testing.T) {
+	recorded := &cassette.Request{
+		Method: http.MethodPost,
+		Url:    "https://api.confluent.cloud/connect/v1/connectors",
+		Body:   json.RawMessage(`{"name":"sink","config":{"kafka.api.secret":"[REDACTED]"}}`),
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:1234/connect/v1/connectors", strings.NewReader(`{"name":"sink","config":{"kafka.api.secret":"live-secret"}}`))
+	assert.True(t, cassette.DefaultMatcher(req, recorded))
+}
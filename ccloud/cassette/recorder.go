@@ -0,0 +1,288 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+)
+
+// Scrubber edits an interaction before it is written, e.g. to replace
+// account specific ids. Secrets are always redacted before scrubbers run.
+type Scrubber func(interaction *Interaction)
+
+// Matcher reports whether a recorded request answers req.
+type Matcher func(req *http.Request, recorded *Request) bool
+
+type Option func(*Recorder)
+
+// ErrNoCassette is returned by New when there is no cassette to replay.
+var ErrNoCassette = errors.New("cassette not found")
+
+// WithTransport sets the transport used to reach the real API in record and
+// live modes.
+func WithTransport(next http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.next = next
+	}
+}
+
+func WithScrubber(scrubber Scrubber) Option {
+	return func(r *Recorder) {
+		r.scrubbers = append(r.scrubbers, scrubber)
+	}
+}
+
+func WithMatcher(matcher Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = matcher
+	}
+}
+
+// Recorder is an http.RoundTripper that records or replays a cassette.
+// Recorded interactions are replayed in order, each one at most once.
+type Recorder struct {
+	filename  string
+	mode      Mode
+	next      http.RoundTripper
+	scrubbers []Scrubber
+	matcher   Matcher
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+func New(filename string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		filename: filename,
+		mode:     mode,
+		next:     http.DefaultTransport,
+		matcher:  DefaultMatcher,
+		cassette: &Cassette{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		cassette, err := Load(filename)
+		if isNotExist(err) {
+			return nil, fmt.Errorf("%w: %s, record it with %s=record", ErrNoCassette, filename, ModeEnv)
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = cassette
+		r.used = make([]bool, len(cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// ClientOptions plugs the recorder into a ConfluentClient or
+// ConfluentClusterClient. When replaying, retries do not wait.
+func (r *Recorder) ClientOptions() []client.Option {
+	opts := []client.Option{client.WithTransport(r)}
+	if r.mode == ModeReplay {
+		opts = append(opts, client.WithRetryPolicy(replayRetryPolicy{client.NewDefaultRetryPolicy()}))
+	}
+	return opts
+}
+
+// replayRetryPolicy retries like the default policy but never waits, since
+// the recorded responses are already there.
+type replayRetryPolicy struct {
+	*client.DefaultRetryPolicy
+}
+
+func (replayRetryPolicy) Backoff(int, *http.Response) time.Duration {
+	return 0
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch r.mode {
+	case ModeReplay:
+		return r.replay(req)
+	case ModeRecord:
+		return r.record(req)
+	}
+	return r.next.RoundTrip(req)
+}
+
+// Stop writes the cassette when recording.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cassette.Save(r.filename)
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(req, &interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		body := getBody(interaction.Response.Body, interaction.Response.Text)
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s: no recorded interaction for %s %s", r.filename, req.Method, req.URL.RequestURI())
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	res, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	interaction := &Interaction{
+		Request:  Request{Method: req.Method, Url: req.URL.String()},
+		Response: Response{StatusCode: res.StatusCode, Header: http.Header{}},
+	}
+	setBody(client.RedactJSON(reqBody), &interaction.Request.Body, &interaction.Request.Text)
+	setBody(client.RedactJSON(resBody), &interaction.Response.Body, &interaction.Response.Text)
+	for _, name := range recordedHeaders {
+		if value := res.Header.Get(name); value != "" {
+			interaction.Response.Header.Set(name, value)
+		}
+	}
+
+	for _, scrub := range r.scrubbers {
+		scrub(interaction)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// DefaultMatcher matches on method, path, query parameters and body,
+// ignoring the host, the order of parameters and the layout of JSON bodies.
+// Secrets are redacted on both sides before the bodies are compared.
+func DefaultMatcher(req *http.Request, recorded *Request) bool {
+	if !MatchIgnoringBody(req, recorded) {
+		return false
+	}
+
+	body, err := requestBody(req)
+	if err != nil {
+		return false
+	}
+
+	return sameBody(client.RedactJSON(body), client.RedactJSON(getBody(recorded.Body, recorded.Text)))
+}
+
+// MatchIgnoringBody is DefaultMatcher without the body comparison. Pass it to
+// WithMatcher when request bodies are not stable between runs.
+func MatchIgnoringBody(req *http.Request, recorded *Request) bool {
+	if req.Method != recorded.Method {
+		return false
+	}
+
+	recordedUrl, err := url.Parse(recorded.Url)
+	if err != nil || recordedUrl.Path != req.URL.Path {
+		return false
+	}
+
+	return maps.EqualFunc(recordedUrl.Query(), req.URL.Query(), slices.Equal)
+}
+
+// requestBody reads the body of req and leaves it readable for the next
+// matcher or transport.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+func sameBody(body, recorded []byte) bool {
+	body, recorded = bytes.TrimSpace(body), bytes.TrimSpace(recorded)
+	if !json.Valid(body) || !json.Valid(recorded) {
+		return bytes.Equal(body, recorded)
+	}
+
+	var got, want any
+	if decodeJSON(body, &got) != nil || decodeJSON(recorded, &want) != nil {
+		return false
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+func decodeJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,300 @@
+package ccloudtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Phases reported in status.phase of cmk/v2 clusters.
+const (
+	PhaseProvisioning   = "PROVISIONING"
+	PhaseProvisioned    = "PROVISIONED"
+	PhaseFailed         = "FAILED"
+	PhaseDeprovisioning = "DEPROVISIONING"
+)
+
+type kafkaClusterConfig struct {
+	Kind  string   `json:"kind"`
+	Cku   int      `json:"cku,omitempty"`
+	Zones []string `json:"zones,omitempty"`
+}
+
+type kafkaClusterSpec struct {
+	DisplayName            string             `json:"display_name"`
+	Availability           string             `json:"availability"`
+	Cloud                  string             `json:"cloud"`
+	Region                 string             `json:"region"`
+	Config                 kafkaClusterConfig `json:"config"`
+	KafkaBootstrapEndpoint string             `json:"kafka_bootstrap_endpoint"`
+	HttpEndpoint           string             `json:"http_endpoint"`
+	ApiEndpoint            string             `json:"api_endpoint"`
+	Environment            objectRef          `json:"environment"`
+}
+
+type kafkaClusterStatus struct {
+	Phase string `json:"phase"`
+	Cku   int    `json:"cku,omitempty"`
+}
+
+type kafkaCluster struct {
+	ApiVersion string             `json:"api_version"`
+	Kind       string             `json:"kind"`
+	Id         string             `json:"id"`
+	Metadata   metadata           `json:"metadata"`
+	Spec       kafkaClusterSpec   `json:"spec"`
+	Status     kafkaClusterStatus `json:"status"`
+
+	// polls left before a provisioning cluster is provisioned.
+	polls  int
+	topics []*topic
+	acls   []*acl
+}
+
+// read advances the phase of a provisioning cluster each time it is read.
+func (c *kafkaCluster) read() {
+	if c.Status.Phase != PhaseProvisioning {
+		return
+	}
+	if c.polls > 0 {
+		c.polls--
+		return
+	}
+	c.Status.Phase = PhaseProvisioned
+}
+
+func (s *Server) routeCmk(mux *http.ServeMux) {
+	mux.HandleFunc("GET /cmk/v2/clusters", s.listKafkaClusters)
+	mux.HandleFunc("POST /cmk/v2/clusters", s.createKafkaCluster)
+	mux.HandleFunc("GET /cmk/v2/clusters/{id}", s.getKafkaCluster)
+	mux.HandleFunc("PATCH /cmk/v2/clusters/{id}", s.updateKafkaCluster)
+	mux.HandleFunc("DELETE /cmk/v2/clusters/{id}", s.deleteKafkaCluster)
+}
+
+// AddKafkaCluster creates a provisioned Basic cluster in an existing
+// environment and returns its id.
+func (s *Server) AddKafkaCluster(environmentId, displayName string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findEnvironment(environmentId) == nil {
+		return "", fmt.Errorf("environment %s not found", environmentId)
+	}
+
+	c := s.addKafkaCluster(environmentId, kafkaClusterSpec{
+		DisplayName:  displayName,
+		Availability: "SINGLE_ZONE",
+		Cloud:        "AWS",
+		Region:       "us-east-1",
+		Config:       kafkaClusterConfig{Kind: "Basic"},
+	})
+	c.Status.Phase = PhaseProvisioned
+
+	return c.Id, nil
+}
+
+// SetKafkaClusterPhase forces the phase of a cluster, e.g. to FAILED.
+func (s *Server) SetKafkaClusterPhase(clusterId, phase string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findKafkaCluster(clusterId)
+	if c == nil {
+		return fmt.Errorf("kafka cluster %s not found", clusterId)
+	}
+
+	c.Status.Phase = phase
+	c.polls = 0
+	return nil
+}
+
+func (s *Server) addKafkaCluster(environmentId string, spec kafkaClusterSpec) *kafkaCluster {
+	id := s.nextId("lkc")
+	host := fmt.Sprintf("pkc-%s.%s.%s.confluent.cloud", strings.TrimPrefix(id, "lkc-"), spec.Region, strings.ToLower(spec.Cloud))
+
+	spec.KafkaBootstrapEndpoint = "SASL_SSL://" + host + ":9092"
+	spec.HttpEndpoint = s.URL
+	spec.ApiEndpoint = "https://pkac-" + strings.TrimPrefix(id, "lkc-") + "." + spec.Region + "." + strings.ToLower(spec.Cloud) + ".confluent.cloud"
+	spec.Environment = objectRef{
+		Id:           environmentId,
+		Related:      s.URL + "/org/v2/environments/" + environmentId,
+		ResourceName: s.crn("/environment=" + environmentId),
+		ApiVersion:   "org/v2",
+		Kind:         "Environment",
+	}
+
+	c := &kafkaCluster{
+		ApiVersion: "cmk/v2",
+		Kind:       "Cluster",
+		Id:         id,
+		Metadata:   s.newMetadata("/cmk/v2/clusters/"+id, s.crn("/environment="+environmentId+"/cloud-cluster="+id)),
+		Spec:       spec,
+		Status:     kafkaClusterStatus{Phase: PhaseProvisioning, Cku: spec.Config.Cku},
+		polls:      s.provisioningPolls,
+	}
+	if s.provisioningPolls == 0 {
+		c.Status.Phase = PhaseProvisioned
+	}
+
+	s.clusters = append(s.clusters, c)
+	return c
+}
+
+func (s *Server) findKafkaCluster(id string) *kafkaCluster {
+	for _, c := range s.clusters {
+		if c.Id == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// clusterInEnvironment looks up a cluster for the cmk endpoints, which
+// require the environment query parameter.
+func (s *Server) clusterInEnvironment(w http.ResponseWriter, r *http.Request) *kafkaCluster {
+	environmentId := r.URL.Query().Get("environment")
+	if environmentId == "" {
+		s.invalid(w, "environment is required")
+		return nil
+	}
+
+	c := s.findKafkaCluster(r.PathValue("id"))
+	if c == nil || c.Spec.Environment.Id != environmentId {
+		s.notFound(w, "Kafka cluster", r.PathValue("id"))
+		return nil
+	}
+
+	return c
+}
+
+func (s *Server) listKafkaClusters(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	environmentId := r.URL.Query().Get("environment")
+	if environmentId == "" {
+		s.invalid(w, "environment is required")
+		return
+	}
+
+	items := filter(s.clusters, func(c *kafkaCluster) bool { return c.Spec.Environment.Id == environmentId })
+	page, meta, err := paginate(r, items, DefaultPageSize)
+	if err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	for _, c := range page {
+		c.read()
+	}
+
+	s.writeJSON(w, http.StatusOK, list[*kafkaCluster]{ApiVersion: "cmk/v2", Kind: "ClusterList", Metadata: meta, Data: nonNil(page)})
+}
+
+func (s *Server) createKafkaCluster(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Spec kafkaClusterSpec `json:"spec"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	spec := req.Spec
+	switch {
+	case spec.DisplayName == "":
+		s.invalid(w, "spec.display_name is required")
+		return
+	case spec.Cloud == "" || spec.Region == "":
+		s.invalid(w, "spec.cloud and spec.region are required")
+		return
+	case !slices.Contains([]string{"Basic", "Standard", "Enterprise", "Dedicated"}, spec.Config.Kind):
+		s.invalid(w, fmt.Sprintf("invalid spec.config.kind %q", spec.Config.Kind))
+		return
+	case spec.Config.Kind == "Dedicated" && spec.Config.Cku < 1:
+		s.invalid(w, "spec.config.cku must be at least 1 for Dedicated clusters")
+		return
+	}
+
+	if s.findEnvironment(spec.Environment.Id) == nil {
+		s.invalid(w, fmt.Sprintf("environment %q not found", spec.Environment.Id))
+		return
+	}
+
+	if spec.Availability == "" {
+		spec.Availability = "SINGLE_ZONE"
+	}
+
+	s.writeJSON(w, http.StatusAccepted, s.addKafkaCluster(spec.Environment.Id, spec))
+}
+
+func (s *Server) getKafkaCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.clusterInEnvironment(w, r)
+	if c == nil {
+		return
+	}
+
+	c.read()
+	s.writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) updateKafkaCluster(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Spec struct {
+			DisplayName string             `json:"display_name"`
+			Config      kafkaClusterConfig `json:"config"`
+			Environment objectRef          `json:"environment"`
+		} `json:"spec"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findKafkaCluster(r.PathValue("id"))
+	if c == nil || c.Spec.Environment.Id != req.Spec.Environment.Id {
+		s.notFound(w, "Kafka cluster", r.PathValue("id"))
+		return
+	}
+
+	if req.Spec.Config.Kind != "" && req.Spec.Config.Kind != c.Spec.Config.Kind {
+		s.invalid(w, "spec.config.kind cannot be changed")
+		return
+	}
+
+	if req.Spec.DisplayName != "" {
+		c.Spec.DisplayName = req.Spec.DisplayName
+	}
+	if req.Spec.Config.Cku > 0 {
+		c.Spec.Config.Cku = req.Spec.Config.Cku
+		c.Status.Cku = req.Spec.Config.Cku
+	}
+	c.Metadata.UpdatedAt = now()
+
+	s.writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) deleteKafkaCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.clusterInEnvironment(w, r)
+	if c == nil {
+		return
+	}
+
+	s.clusters = slices.DeleteFunc(s.clusters, func(item *kafkaCluster) bool { return item == c })
+	s.connectors = slices.DeleteFunc(s.connectors, func(conn *connector) bool { return conn.clusterId == c.Id })
+	s.apiKeys = slices.DeleteFunc(s.apiKeys, func(key *apiKey) bool { return key.Spec.Resource.Id == c.Id })
+	s.writeStatus(w, http.StatusNoContent)
+}
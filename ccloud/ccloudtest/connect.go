@@ -0,0 +1,377 @@
+package ccloudtest
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+)
+
+// Connector states reported by the connect/v1 status endpoint.
+const (
+	ConnectorStateProvisioning = "PROVISIONING"
+	ConnectorStateRunning      = "RUNNING"
+	ConnectorStatePaused       = "PAUSED"
+	ConnectorStateFailed       = "FAILED"
+)
+
+// maskedValue replaces sensitive configs in responses, as the real API does.
+const maskedValue = "****************"
+
+type connector struct {
+	id            string
+	environmentId string
+	clusterId     string
+	name          string
+	config        map[string]any
+	state         string
+	trace         string
+	polls         int
+}
+
+type connectorTask struct {
+	Connector string `json:"connector"`
+	Task      int    `json:"task"`
+}
+
+type connectorInfo struct {
+	Name   string          `json:"name"`
+	Config map[string]any  `json:"config"`
+	Tasks  []connectorTask `json:"tasks"`
+	Type   string          `json:"type"`
+}
+
+type connectorTaskStatus struct {
+	Id       int    `json:"id"`
+	State    string `json:"state"`
+	WorkerId string `json:"worker_id"`
+	Trace    string `json:"trace,omitempty"`
+}
+
+type connectorStatus struct {
+	Name      string `json:"name"`
+	Connector struct {
+		State    string `json:"state"`
+		WorkerId string `json:"worker_id"`
+		Trace    string `json:"trace,omitempty"`
+	} `json:"connector"`
+	Tasks []connectorTaskStatus `json:"tasks"`
+	Type  string                `json:"type"`
+}
+
+func (c *connector) kind() string {
+	class, _ := c.config["connector.class"].(string)
+	if strings.Contains(strings.ToUpper(class), "SINK") {
+		return "sink"
+	}
+	return "source"
+}
+
+func (c *connector) tasks() int {
+	if c.state != ConnectorStateRunning && c.state != ConnectorStatePaused {
+		return 0
+	}
+	if tasks, err := strconv.Atoi(fmt.Sprint(c.config["tasks.max"])); err == nil && tasks > 0 {
+		return tasks
+	}
+	return 1
+}
+
+func (c *connector) info() connectorInfo {
+	config := maps.Clone(c.config)
+	for key := range config {
+		if client.IsSensitiveKey(key) {
+			config[key] = maskedValue
+		}
+	}
+
+	info := connectorInfo{Name: c.name, Config: config, Tasks: []connectorTask{}, Type: c.kind()}
+	for i := range c.tasks() {
+		info.Tasks = append(info.Tasks, connectorTask{Connector: c.name, Task: i})
+	}
+	return info
+}
+
+func (c *connector) status() connectorStatus {
+	status := connectorStatus{Name: c.name, Tasks: []connectorTaskStatus{}, Type: c.kind()}
+	status.Connector.State = c.state
+	status.Connector.WorkerId = c.name
+	status.Connector.Trace = c.trace
+	for i := range c.tasks() {
+		status.Tasks = append(status.Tasks, connectorTaskStatus{Id: i, State: c.state, WorkerId: c.name})
+	}
+	return status
+}
+
+// read advances a provisioning connector each time its status is read.
+func (c *connector) read() {
+	if c.state != ConnectorStateProvisioning {
+		return
+	}
+	if c.polls > 0 {
+		c.polls--
+		return
+	}
+	c.state = ConnectorStateRunning
+}
+
+func (s *Server) routeConnect(mux *http.ServeMux) {
+	const base = "/connect/v1/environments/{env}/clusters/{cluster}/connectors"
+
+	mux.HandleFunc("GET "+base, s.listConnectors)
+	mux.HandleFunc("POST "+base, s.createConnector)
+	mux.HandleFunc("GET "+base+"/{name}", s.getConnector)
+	mux.HandleFunc("DELETE "+base+"/{name}", s.deleteConnector)
+	mux.HandleFunc("GET "+base+"/{name}/status", s.getConnectorStatus)
+	mux.HandleFunc("GET "+base+"/{name}/config", s.getConnectorConfig)
+	mux.HandleFunc("PUT "+base+"/{name}/config", s.putConnectorConfig)
+	mux.HandleFunc("PUT "+base+"/{name}/pause", s.setConnectorState(ConnectorStatePaused))
+	mux.HandleFunc("PUT "+base+"/{name}/resume", s.setConnectorState(ConnectorStateRunning))
+	mux.HandleFunc("POST "+base+"/{name}/restart", s.setConnectorState(ConnectorStateRunning))
+}
+
+// SetConnectorState forces the state of a connector, e.g. to FAILED with a
+// stack trace.
+func (s *Server) SetConnectorState(environmentId, clusterId, name, state, trace string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findConnector(environmentId, clusterId, name)
+	if c == nil {
+		return fmt.Errorf("connector %s not found", name)
+	}
+
+	c.state = state
+	c.trace = trace
+	c.polls = 0
+	return nil
+}
+
+func (s *Server) connectError(w http.ResponseWriter, status int, message string) {
+	s.writeJSON(w, status, map[string]any{
+		"error": map[string]any{"code": status, "message": message},
+	})
+}
+
+// connectCluster checks the environment and cluster of a connect request.
+func (s *Server) connectCluster(w http.ResponseWriter, r *http.Request) bool {
+	c := s.findKafkaCluster(r.PathValue("cluster"))
+	if c == nil || c.Spec.Environment.Id != r.PathValue("env") {
+		s.connectError(w, http.StatusNotFound, fmt.Sprintf("Kafka cluster %s not found in environment %s", r.PathValue("cluster"), r.PathValue("env")))
+		return false
+	}
+	return true
+}
+
+func (s *Server) findConnector(environmentId, clusterId, name string) *connector {
+	for _, c := range s.connectors {
+		if c.environmentId == environmentId && c.clusterId == clusterId && c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *Server) connector(w http.ResponseWriter, r *http.Request) *connector {
+	if !s.connectCluster(w, r) {
+		return nil
+	}
+
+	c := s.findConnector(r.PathValue("env"), r.PathValue("cluster"), r.PathValue("name"))
+	if c == nil {
+		s.connectError(w, http.StatusNotFound, fmt.Sprintf("Connector %s not found", r.PathValue("name")))
+		return nil
+	}
+	return c
+}
+
+// listConnectors returns the connector names, or a map of the expansions
+// requested with expand=id,info,status.
+func (s *Server) listConnectors(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.connectCluster(w, r) {
+		return
+	}
+
+	connectors := filter(s.connectors, func(c *connector) bool {
+		return c.environmentId == r.PathValue("env") && c.clusterId == r.PathValue("cluster")
+	})
+
+	expand := r.URL.Query().Get("expand")
+	if expand == "" {
+		names := []string{}
+		for _, c := range connectors {
+			names = append(names, c.name)
+		}
+		s.writeJSON(w, http.StatusOK, names)
+		return
+	}
+
+	expansions := strings.Split(expand, ",")
+	result := map[string]map[string]any{}
+	for _, c := range connectors {
+		c.read()
+		item := map[string]any{}
+		if slices.Contains(expansions, "id") {
+			item["id"] = map[string]string{"id": c.id, "id_type": "ID"}
+		}
+		if slices.Contains(expansions, "info") {
+			item["info"] = c.info()
+		}
+		if slices.Contains(expansions, "status") {
+			item["status"] = c.status()
+		}
+		result[c.name] = item
+	}
+
+	s.writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createConnector(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name   string         `json:"name"`
+		Config map[string]any `json:"config"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.connectError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.connectCluster(w, r) {
+		return
+	}
+
+	if req.Name == "" {
+		s.connectError(w, http.StatusBadRequest, "Connector name is required")
+		return
+	}
+	if _, ok := req.Config["connector.class"]; !ok {
+		s.connectError(w, http.StatusBadRequest, "Connector configuration is invalid: connector.class is required")
+		return
+	}
+	if s.findConnector(r.PathValue("env"), r.PathValue("cluster"), req.Name) != nil {
+		s.connectError(w, http.StatusConflict, fmt.Sprintf("Connector %s already exists", req.Name))
+		return
+	}
+
+	c := s.addConnector(r.PathValue("env"), r.PathValue("cluster"), req.Name, req.Config)
+	s.writeJSON(w, http.StatusCreated, c.info())
+}
+
+func (s *Server) addConnector(environmentId, clusterId, name string, config map[string]any) *connector {
+	c := &connector{
+		id:            s.nextId("lcc"),
+		environmentId: environmentId,
+		clusterId:     clusterId,
+		name:          name,
+		config:        config,
+		state:         ConnectorStateProvisioning,
+		polls:         s.provisioningPolls,
+	}
+	if s.provisioningPolls == 0 {
+		c.state = ConnectorStateRunning
+	}
+
+	s.connectors = append(s.connectors, c)
+	return c
+}
+
+func (s *Server) getConnector(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c := s.connector(w, r); c != nil {
+		s.writeJSON(w, http.StatusOK, c.info())
+	}
+}
+
+func (s *Server) getConnectorStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c := s.connector(w, r); c != nil {
+		c.read()
+		s.writeJSON(w, http.StatusOK, c.status())
+	}
+}
+
+func (s *Server) getConnectorConfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c := s.connector(w, r); c != nil {
+		s.writeJSON(w, http.StatusOK, c.info().Config)
+	}
+}
+
+// putConnectorConfig replaces the config of a connector, creating it when it
+// does not exist.
+func (s *Server) putConnectorConfig(w http.ResponseWriter, r *http.Request) {
+	var config map[string]any
+	if err := decode(r, &config); err != nil {
+		s.connectError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.connectCluster(w, r) {
+		return
+	}
+
+	if _, ok := config["connector.class"]; !ok {
+		s.connectError(w, http.StatusBadRequest, "Connector configuration is invalid: connector.class is required")
+		return
+	}
+
+	c := s.findConnector(r.PathValue("env"), r.PathValue("cluster"), r.PathValue("name"))
+	if c == nil {
+		c = s.addConnector(r.PathValue("env"), r.PathValue("cluster"), r.PathValue("name"), config)
+		s.writeJSON(w, http.StatusCreated, c.info())
+		return
+	}
+
+	c.config = config
+	s.writeJSON(w, http.StatusOK, c.info())
+}
+
+func (s *Server) setConnectorState(state string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		c := s.connector(w, r)
+		if c == nil {
+			return
+		}
+
+		if c.state != ConnectorStateProvisioning {
+			c.state = state
+			c.trace = ""
+		}
+		s.writeStatus(w, http.StatusAccepted)
+	}
+}
+
+func (s *Server) deleteConnector(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.connector(w, r)
+	if c == nil {
+		return
+	}
+
+	s.connectors = slices.DeleteFunc(s.connectors, func(item *connector) bool { return item == c })
+	s.writeJSON(w, http.StatusOK, map[string]any{"error": nil})
+}
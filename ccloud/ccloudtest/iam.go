@@ -0,0 +1,1130 @@
+package ccloudtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+type serviceAccount struct {
+	ApiVersion  string   `json:"api_version"`
+	Kind        string   `json:"kind"`
+	Id          string   `json:"id"`
+	Metadata    metadata `json:"metadata"`
+	DisplayName string   `json:"display_name"`
+	Description string   `json:"description"`
+}
+
+type apiKeySpec struct {
+	DisplayName string    `json:"display_name"`
+	Description string    `json:"description"`
+	Secret      string    `json:"secret,omitempty"`
+	Owner       objectRef `json:"owner"`
+	Resource    objectRef `json:"resource"`
+}
+
+type apiKey struct {
+	ApiVersion string     `json:"api_version"`
+	Kind       string     `json:"kind"`
+	Id         string     `json:"id"`
+	Metadata   metadata   `json:"metadata"`
+	Spec       apiKeySpec `json:"spec"`
+}
+
+type roleBinding struct {
+	ApiVersion string   `json:"api_version"`
+	Kind       string   `json:"kind"`
+	Id         string   `json:"id"`
+	Metadata   metadata `json:"metadata"`
+	Principal  string   `json:"principal"`
+	RoleName   string   `json:"role_name"`
+	CrnPattern string   `json:"crn_pattern"`
+}
+
+type user struct {
+	ApiVersion string   `json:"api_version"`
+	Kind       string   `json:"kind"`
+	Id         string   `json:"id"`
+	Metadata   metadata `json:"metadata"`
+	Email      string   `json:"email"`
+	FullName   string   `json:"full_name"`
+	AuthType   string   `json:"auth_type"`
+}
+
+type invitation struct {
+	ApiVersion string    `json:"api_version"`
+	Kind       string    `json:"kind"`
+	Id         string    `json:"id"`
+	Metadata   metadata  `json:"metadata"`
+	Email      string    `json:"email"`
+	AuthType   string    `json:"auth_type"`
+	Status     string    `json:"status"`
+	AcceptedAt string    `json:"accepted_at,omitempty"`
+	ExpiresAt  string    `json:"expires_at"`
+	User       objectRef `json:"user"`
+	Creator    objectRef `json:"creator"`
+}
+
+type identityProvider struct {
+	ApiVersion  string   `json:"api_version"`
+	Kind        string   `json:"kind"`
+	Id          string   `json:"id"`
+	Metadata    metadata `json:"metadata"`
+	DisplayName string   `json:"display_name"`
+	Description string   `json:"description"`
+	State       string   `json:"state"`
+	Issuer      string   `json:"issuer"`
+	JwksUri     string   `json:"jwks_uri"`
+}
+
+type identityPool struct {
+	ApiVersion    string   `json:"api_version"`
+	Kind          string   `json:"kind"`
+	Id            string   `json:"id"`
+	Metadata      metadata `json:"metadata"`
+	DisplayName   string   `json:"display_name"`
+	Description   string   `json:"description"`
+	IdentityClaim string   `json:"identity_claim"`
+	Filter        string   `json:"filter"`
+	State         string   `json:"state"`
+
+	provider string
+}
+
+func (s *Server) routeIam(mux *http.ServeMux) {
+	mux.HandleFunc("GET /iam/v2/service-accounts", s.listServiceAccounts)
+	mux.HandleFunc("POST /iam/v2/service-accounts", s.createServiceAccount)
+	mux.HandleFunc("GET /iam/v2/service-accounts/{id}", s.getServiceAccount)
+	mux.HandleFunc("PATCH /iam/v2/service-accounts/{id}", s.updateServiceAccount)
+	mux.HandleFunc("DELETE /iam/v2/service-accounts/{id}", s.deleteServiceAccount)
+
+	mux.HandleFunc("GET /iam/v2/api-keys", s.listApiKeys)
+	mux.HandleFunc("POST /iam/v2/api-keys", s.createApiKey)
+	mux.HandleFunc("GET /iam/v2/api-keys/{id}", s.getApiKey)
+	mux.HandleFunc("PATCH /iam/v2/api-keys/{id}", s.updateApiKey)
+	mux.HandleFunc("DELETE /iam/v2/api-keys/{id}", s.deleteApiKey)
+
+	mux.HandleFunc("GET /iam/v2/role-bindings", s.listRoleBindings)
+	mux.HandleFunc("POST /iam/v2/role-bindings", s.createRoleBinding)
+	mux.HandleFunc("GET /iam/v2/role-bindings/{id}", s.getRoleBinding)
+	mux.HandleFunc("DELETE /iam/v2/role-bindings/{id}", s.deleteRoleBinding)
+
+	mux.HandleFunc("GET /iam/v2/users", s.listUsers)
+	mux.HandleFunc("GET /iam/v2/users/{id}", s.getUser)
+	mux.HandleFunc("PATCH /iam/v2/users/{id}", s.updateUser)
+	mux.HandleFunc("DELETE /iam/v2/users/{id}", s.deleteUser)
+
+	mux.HandleFunc("GET /iam/v2/invitations", s.listInvitations)
+	mux.HandleFunc("POST /iam/v2/invitations", s.createInvitation)
+	mux.HandleFunc("GET /iam/v2/invitations/{id}", s.getInvitation)
+	mux.HandleFunc("DELETE /iam/v2/invitations/{id}", s.deleteInvitation)
+
+	mux.HandleFunc("GET /iam/v2/identity-providers", s.listIdentityProviders)
+	mux.HandleFunc("POST /iam/v2/identity-providers", s.createIdentityProvider)
+	mux.HandleFunc("GET /iam/v2/identity-providers/{id}", s.getIdentityProvider)
+	mux.HandleFunc("PATCH /iam/v2/identity-providers/{id}", s.updateIdentityProvider)
+	mux.HandleFunc("DELETE /iam/v2/identity-providers/{id}", s.deleteIdentityProvider)
+
+	mux.HandleFunc("GET /iam/v2/identity-providers/{provider}/identity-pools", s.listIdentityPools)
+	mux.HandleFunc("POST /iam/v2/identity-providers/{provider}/identity-pools", s.createIdentityPool)
+	mux.HandleFunc("GET /iam/v2/identity-providers/{provider}/identity-pools/{id}", s.getIdentityPool)
+	mux.HandleFunc("PATCH /iam/v2/identity-providers/{provider}/identity-pools/{id}", s.updateIdentityPool)
+	mux.HandleFunc("DELETE /iam/v2/identity-providers/{provider}/identity-pools/{id}", s.deleteIdentityPool)
+}
+
+// AddServiceAccount creates a service account and returns its id.
+func (s *Server) AddServiceAccount(displayName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addServiceAccount(displayName, "").Id
+}
+
+func (s *Server) addServiceAccount(displayName, description string) *serviceAccount {
+	id := s.nextId("sa")
+	sa := &serviceAccount{
+		ApiVersion:  "iam/v2",
+		Kind:        "ServiceAccount",
+		Id:          id,
+		Metadata:    s.newMetadata("/iam/v2/service-accounts/"+id, s.crn("/service-account="+id)),
+		DisplayName: displayName,
+		Description: description,
+	}
+	s.serviceAccounts = append(s.serviceAccounts, sa)
+	return sa
+}
+
+func (s *Server) findServiceAccount(id string) *serviceAccount {
+	for _, sa := range s.serviceAccounts {
+		if sa.Id == id {
+			return sa
+		}
+	}
+	return nil
+}
+
+func (s *Server) listServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.serviceAccounts
+	if names := r.URL.Query()["display_name"]; len(names) > 0 {
+		items = filter(items, func(sa *serviceAccount) bool { return slices.Contains(names, sa.DisplayName) })
+	}
+
+	page, meta, err := paginate(r, items, DefaultPageSize)
+	if err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, list[*serviceAccount]{ApiVersion: "iam/v2", Kind: "ServiceAccountList", Metadata: meta, Data: nonNil(page)})
+}
+
+func (s *Server) createServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		DisplayName string `json:"display_name"`
+		Description string `json:"description"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.DisplayName == "" {
+		s.invalid(w, "display_name is required")
+		return
+	}
+
+	if slices.ContainsFunc(s.serviceAccounts, func(sa *serviceAccount) bool { return sa.DisplayName == req.DisplayName }) {
+		s.error(w, http.StatusConflict, "already_exists", fmt.Sprintf("Service name %q is already in use.", req.DisplayName))
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, s.addServiceAccount(req.DisplayName, req.Description))
+}
+
+func (s *Server) getServiceAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sa := s.findServiceAccount(r.PathValue("id"))
+	if sa == nil {
+		s.notFound(w, "service account", r.PathValue("id"))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, sa)
+}
+
+func (s *Server) updateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Description *string `json:"description"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sa := s.findServiceAccount(r.PathValue("id"))
+	if sa == nil {
+		s.notFound(w, "service account", r.PathValue("id"))
+		return
+	}
+
+	if req.Description != nil {
+		sa.Description = *req.Description
+		sa.Metadata.UpdatedAt = now()
+	}
+
+	s.writeJSON(w, http.StatusOK, sa)
+}
+
+func (s *Server) deleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.findServiceAccount(id) == nil {
+		s.notFound(w, "service account", id)
+		return
+	}
+
+	// Deleting a service account revokes its keys and role bindings.
+	s.serviceAccounts = slices.DeleteFunc(s.serviceAccounts, func(sa *serviceAccount) bool { return sa.Id == id })
+	s.apiKeys = slices.DeleteFunc(s.apiKeys, func(k *apiKey) bool { return k.Spec.Owner.Id == id })
+	s.roleBindings = slices.DeleteFunc(s.roleBindings, func(rb *roleBinding) bool { return rb.Principal == "User:"+id })
+	s.writeStatus(w, http.StatusNoContent)
+}
+
+func (s *Server) findApiKey(id string) *apiKey {
+	for _, key := range s.apiKeys {
+		if key.Id == id {
+			return key
+		}
+	}
+	return nil
+}
+
+func (s *Server) listApiKeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	items := filter(s.apiKeys, func(key *apiKey) bool {
+		return (query.Get("spec.owner") == "" || key.Spec.Owner.Id == query.Get("spec.owner")) &&
+			(query.Get("spec.resource") == "" || key.Spec.Resource.Id == query.Get("spec.resource"))
+	})
+
+	page, meta, err := paginate(r, items, DefaultPageSize)
+	if err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, list[*apiKey]{ApiVersion: "iam/v2", Kind: "ApiKeyList", Metadata: meta, Data: nonNil(page)})
+}
+
+func (s *Server) createApiKey(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Spec struct {
+			DisplayName string    `json:"display_name"`
+			Description string    `json:"description"`
+			Owner       objectRef `json:"owner"`
+			Resource    objectRef `json:"resource"`
+		} `json:"spec"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	owner, ok := s.ownerRef(req.Spec.Owner.Id)
+	if !ok {
+		s.invalid(w, fmt.Sprintf("invalid owner %q", req.Spec.Owner.Id))
+		return
+	}
+
+	resource, ok := s.resourceRef(req.Spec.Resource.Id)
+	if !ok {
+		s.invalid(w, fmt.Sprintf("invalid resource %q", req.Spec.Resource.Id))
+		return
+	}
+
+	s.seq["api-key"]++
+	id := fmt.Sprintf("CCLOUDTEST%06d", s.seq["api-key"])
+	key := &apiKey{
+		ApiVersion: "iam/v2",
+		Kind:       "ApiKey",
+		Id:         id,
+		Metadata:   s.newMetadata("/iam/v2/api-keys/"+id, s.crn("/api-key="+id)),
+		Spec: apiKeySpec{
+			DisplayName: req.Spec.DisplayName,
+			Description: req.Spec.Description,
+			Owner:       owner,
+			Resource:    resource,
+		},
+	}
+	s.apiKeys = append(s.apiKeys, key)
+
+	// The secret is only returned by the create call.
+	created := *key
+	created.Spec.Secret = newSecret()
+	s.writeJSON(w, http.StatusAccepted, &created)
+}
+
+func (s *Server) ownerRef(id string) (objectRef, bool) {
+	switch {
+	case s.findServiceAccount(id) != nil:
+		return objectRef{
+			Id:           id,
+			Related:      s.URL + "/iam/v2/service-accounts/" + id,
+			ResourceName: s.crn("/service-account=" + id),
+			ApiVersion:   "iam/v2",
+			Kind:         "ServiceAccount",
+		}, true
+	case strings.HasPrefix(id, "u-"):
+		return objectRef{
+			Id:           id,
+			Related:      s.URL + "/iam/v2/users/" + id,
+			ResourceName: s.crn("/user=" + id),
+			ApiVersion:   "iam/v2",
+			Kind:         "User",
+		}, true
+	}
+	return objectRef{}, false
+}
+
+// resourceRef resolves the resource of an API key, a Kafka cluster or, when
+// empty, the cloud API.
+func (s *Server) resourceRef(id string) (objectRef, bool) {
+	if id == "" {
+		return objectRef{Id: "cloud", Kind: "Cloud"}, true
+	}
+
+	c := s.findKafkaCluster(id)
+	if c == nil {
+		return objectRef{}, false
+	}
+
+	return objectRef{
+		Id:           id,
+		Environment:  c.Spec.Environment.Id,
+		Related:      s.URL + "/cmk/v2/clusters/" + id,
+		ResourceName: c.Metadata.ResourceName,
+		ApiVersion:   "cmk/v2",
+		Kind:         "Cluster",
+	}, true
+}
+
+func newSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (s *Server) getApiKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.findApiKey(r.PathValue("id"))
+	if key == nil {
+		s.notFound(w, "API key", r.PathValue("id"))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, key)
+}
+
+func (s *Server) updateApiKey(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Spec struct {
+			DisplayName *string `json:"display_name"`
+			Description *string `json:"description"`
+		} `json:"spec"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.findApiKey(r.PathValue("id"))
+	if key == nil {
+		s.notFound(w, "API key", r.PathValue("id"))
+		return
+	}
+
+	if req.Spec.DisplayName != nil {
+		key.Spec.DisplayName = *req.Spec.DisplayName
+	}
+	if req.Spec.Description != nil {
+		key.Spec.Description = *req.Spec.Description
+	}
+	key.Metadata.UpdatedAt = now()
+
+	s.writeJSON(w, http.StatusOK, key)
+}
+
+func (s *Server) deleteApiKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.findApiKey(id) == nil {
+		s.notFound(w, "API key", id)
+		return
+	}
+
+	s.apiKeys = slices.DeleteFunc(s.apiKeys, func(key *apiKey) bool { return key.Id == id })
+	s.writeStatus(w, http.StatusNoContent)
+}
+
+func (s *Server) findRoleBinding(id string) *roleBinding {
+	for _, rb := range s.roleBindings {
+		if rb.Id == id {
+			return rb
+		}
+	}
+	return nil
+}
+
+// listRoleBindings requires crn_pattern, like the real API, and returns the
+// bindings granted on that scope or below it.
+func (s *Server) listRoleBindings(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	scope := query.Get("crn_pattern")
+	if scope == "" {
+		s.invalid(w, "crn_pattern is required")
+		return
+	}
+
+	items := filter(s.roleBindings, func(rb *roleBinding) bool {
+		return (rb.CrnPattern == scope || strings.HasPrefix(rb.CrnPattern, scope+"/")) &&
+			(query.Get("principal") == "" || rb.Principal == query.Get("principal")) &&
+			(query.Get("role_name") == "" || rb.RoleName == query.Get("role_name"))
+	})
+
+	page, meta, err := paginate(r, items, DefaultPageSize)
+	if err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, list[*roleBinding]{ApiVersion: "iam/v2", Kind: "RoleBindingList", Metadata: meta, Data: nonNil(page)})
+}
+
+func (s *Server) createRoleBinding(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Principal  string `json:"principal"`
+		RoleName   string `json:"role_name"`
+		CrnPattern string `json:"crn_pattern"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case !strings.HasPrefix(req.Principal, "User:"):
+		s.invalid(w, fmt.Sprintf("invalid principal %q", req.Principal))
+		return
+	case req.RoleName == "":
+		s.invalid(w, "role_name is required")
+		return
+	case !strings.HasPrefix(req.CrnPattern, "crn://"):
+		s.invalid(w, fmt.Sprintf("invalid crn_pattern %q", req.CrnPattern))
+		return
+	}
+
+	if slices.ContainsFunc(s.roleBindings, func(rb *roleBinding) bool {
+		return rb.Principal == req.Principal && rb.RoleName == req.RoleName && rb.CrnPattern == req.CrnPattern
+	}) {
+		s.error(w, http.StatusConflict, "already_exists", "The role binding already exists.")
+		return
+	}
+
+	id := s.nextId("rb")
+	rb := &roleBinding{
+		ApiVersion: "iam/v2",
+		Kind:       "RoleBinding",
+		Id:         id,
+		Metadata:   s.newMetadata("/iam/v2/role-bindings/"+id, s.crn("/role-binding="+id)),
+		Principal:  req.Principal,
+		RoleName:   req.RoleName,
+		CrnPattern: req.CrnPattern,
+	}
+	s.roleBindings = append(s.roleBindings, rb)
+
+	s.writeJSON(w, http.StatusCreated, rb)
+}
+
+func (s *Server) getRoleBinding(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rb := s.findRoleBinding(r.PathValue("id"))
+	if rb == nil {
+		s.notFound(w, "role binding", r.PathValue("id"))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, rb)
+}
+
+// deleteRoleBinding answers 200 with the deleted binding, as the real API does.
+func (s *Server) deleteRoleBinding(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rb := s.findRoleBinding(r.PathValue("id"))
+	if rb == nil {
+		s.notFound(w, "role binding", r.PathValue("id"))
+		return
+	}
+
+	s.roleBindings = slices.DeleteFunc(s.roleBindings, func(item *roleBinding) bool { return item == rb })
+	s.writeJSON(w, http.StatusOK, rb)
+}
+
+// AddUser creates a user, as if it had accepted an invitation, and returns
+// its id.
+func (s *Server) AddUser(email, fullName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.addUser(email, "AUTH_TYPE_LOCAL")
+	u.FullName = fullName
+	return u.Id
+}
+
+func (s *Server) addUser(email, authType string) *user {
+	id := s.nextId("u")
+	u := &user{
+		ApiVersion: "iam/v2",
+		Kind:       "User",
+		Id:         id,
+		Metadata:   s.newMetadata("/iam/v2/users/"+id, s.crn("/user="+id)),
+		Email:      email,
+		AuthType:   authType,
+	}
+	s.users = append(s.users, u)
+	return u
+}
+
+func (s *Server) findUser(id string) *user {
+	for _, u := range s.users {
+		if u.Id == id {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	page, meta, err := paginate(r, s.users, DefaultPageSize)
+	if err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, list[*user]{ApiVersion: "iam/v2", Kind: "UserList", Metadata: meta, Data: nonNil(page)})
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.findUser(r.PathValue("id"))
+	if u == nil {
+		s.notFound(w, "user", r.PathValue("id"))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, u)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FullName *string `json:"full_name"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.findUser(r.PathValue("id"))
+	if u == nil {
+		s.notFound(w, "user", r.PathValue("id"))
+		return
+	}
+
+	if req.FullName != nil {
+		u.FullName = *req.FullName
+		u.Metadata.UpdatedAt = now()
+	}
+
+	s.writeJSON(w, http.StatusOK, u)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.findUser(id) == nil {
+		s.notFound(w, "user", id)
+		return
+	}
+
+	// Deleting a user revokes its keys, role bindings and invitations.
+	s.users = slices.DeleteFunc(s.users, func(u *user) bool { return u.Id == id })
+	s.apiKeys = slices.DeleteFunc(s.apiKeys, func(k *apiKey) bool { return k.Spec.Owner.Id == id })
+	s.roleBindings = slices.DeleteFunc(s.roleBindings, func(rb *roleBinding) bool { return rb.Principal == "User:"+id })
+	s.invitations = slices.DeleteFunc(s.invitations, func(inv *invitation) bool { return inv.User.Id == id })
+	s.writeStatus(w, http.StatusNoContent)
+}
+
+// AcceptInvitation accepts an invitation on behalf of the invited user.
+func (s *Server) AcceptInvitation(invitationId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inv := s.findInvitation(invitationId)
+	if inv == nil {
+		return fmt.Errorf("invitation %s not found", invitationId)
+	}
+	if inv.Status != "INVITE_STATUS_SENT" {
+		return fmt.Errorf("invitation %s is %s", invitationId, inv.Status)
+	}
+
+	inv.Status = "INVITE_STATUS_ACCEPTED"
+	inv.AcceptedAt = now()
+	inv.Metadata.UpdatedAt = inv.AcceptedAt
+	return nil
+}
+
+func (s *Server) findInvitation(id string) *invitation {
+	for _, inv := range s.invitations {
+		if inv.Id == id {
+			return inv
+		}
+	}
+	return nil
+}
+
+func (s *Server) listInvitations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	page, meta, err := paginate(r, s.invitations, DefaultPageSize)
+	if err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, list[*invitation]{ApiVersion: "iam/v2", Kind: "InvitationList", Metadata: meta, Data: nonNil(page)})
+}
+
+// createInvitation creates the invited user right away, so that it can be
+// given role bindings before the invitation is accepted.
+func (s *Server) createInvitation(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email    string `json:"email"`
+		AuthType string `json:"auth_type"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.Contains(req.Email, "@") {
+		s.invalid(w, fmt.Sprintf("invalid email %q", req.Email))
+		return
+	}
+	if req.AuthType == "" {
+		req.AuthType = "AUTH_TYPE_LOCAL"
+	}
+
+	if slices.ContainsFunc(s.users, func(u *user) bool { return strings.EqualFold(u.Email, req.Email) }) {
+		s.error(w, http.StatusConflict, "already_exists", fmt.Sprintf("User %q is already a member or invited.", req.Email))
+		return
+	}
+
+	u := s.addUser(req.Email, req.AuthType)
+
+	id := s.nextId("i")
+	inv := &invitation{
+		ApiVersion: "iam/v2",
+		Kind:       "Invitation",
+		Id:         id,
+		Metadata:   s.newMetadata("/iam/v2/invitations/"+id, s.crn("/invitation="+id)),
+		Email:      req.Email,
+		AuthType:   req.AuthType,
+		Status:     "INVITE_STATUS_SENT",
+		ExpiresAt:  time.Now().UTC().Add(30 * 24 * time.Hour).Format(time.RFC3339),
+		User: objectRef{
+			Id:           u.Id,
+			Related:      s.URL + "/iam/v2/users/" + u.Id,
+			ResourceName: u.Metadata.ResourceName,
+		},
+		Creator: objectRef{Id: "u-ccloudtest"},
+	}
+	s.invitations = append(s.invitations, inv)
+
+	s.writeJSON(w, http.StatusCreated, inv)
+}
+
+func (s *Server) getInvitation(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inv := s.findInvitation(r.PathValue("id"))
+	if inv == nil {
+		s.notFound(w, "invitation", r.PathValue("id"))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, inv)
+}
+
+// deleteInvitation revokes a pending invitation along with its user.
+func (s *Server) deleteInvitation(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inv := s.findInvitation(r.PathValue("id"))
+	if inv == nil {
+		s.notFound(w, "invitation", r.PathValue("id"))
+		return
+	}
+
+	s.invitations = slices.DeleteFunc(s.invitations, func(item *invitation) bool { return item == inv })
+	if inv.Status == "INVITE_STATUS_SENT" {
+		s.users = slices.DeleteFunc(s.users, func(u *user) bool { return u.Id == inv.User.Id })
+	}
+	s.writeStatus(w, http.StatusNoContent)
+}
+
+// AddIdentityProvider creates an OIDC identity provider and returns its id.
+func (s *Server) AddIdentityProvider(displayName, issuer string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addIdentityProvider(displayName, "", issuer, issuer+"/.well-known/jwks.json").Id
+}
+
+func (s *Server) addIdentityProvider(displayName, description, issuer, jwksUri string) *identityProvider {
+	id := s.nextId("op")
+	provider := &identityProvider{
+		ApiVersion:  "iam/v2",
+		Kind:        "IdentityProvider",
+		Id:          id,
+		Metadata:    s.newMetadata("/iam/v2/identity-providers/"+id, s.crn("/identity-provider="+id)),
+		DisplayName: displayName,
+		Description: description,
+		State:       "enabled",
+		Issuer:      issuer,
+		JwksUri:     jwksUri,
+	}
+	s.identityProviders = append(s.identityProviders, provider)
+	return provider
+}
+
+func (s *Server) findIdentityProvider(id string) *identityProvider {
+	for _, provider := range s.identityProviders {
+		if provider.Id == id {
+			return provider
+		}
+	}
+	return nil
+}
+
+func (s *Server) listIdentityProviders(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	page, meta, err := paginate(r, s.identityProviders, DefaultPageSize)
+	if err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, list[*identityProvider]{ApiVersion: "iam/v2", Kind: "IdentityProviderList", Metadata: meta, Data: nonNil(page)})
+}
+
+func (s *Server) createIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		DisplayName string `json:"display_name"`
+		Description string `json:"description"`
+		Issuer      string `json:"issuer"`
+		JwksUri     string `json:"jwks_uri"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case req.DisplayName == "":
+		s.invalid(w, "display_name is required")
+		return
+	case !strings.HasPrefix(req.Issuer, "https://"):
+		s.invalid(w, fmt.Sprintf("issuer %q must be an https url", req.Issuer))
+		return
+	case !strings.HasPrefix(req.JwksUri, "https://"):
+		s.invalid(w, fmt.Sprintf("jwks_uri %q must be an https url", req.JwksUri))
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, s.addIdentityProvider(req.DisplayName, req.Description, req.Issuer, req.JwksUri))
+}
+
+func (s *Server) getIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	provider := s.findIdentityProvider(r.PathValue("id"))
+	if provider == nil {
+		s.notFound(w, "identity provider", r.PathValue("id"))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, provider)
+}
+
+func (s *Server) updateIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		DisplayName *string `json:"display_name"`
+		Description *string `json:"description"`
+		Issuer      *string `json:"issuer"`
+		JwksUri     *string `json:"jwks_uri"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	provider := s.findIdentityProvider(r.PathValue("id"))
+	if provider == nil {
+		s.notFound(w, "identity provider", r.PathValue("id"))
+		return
+	}
+
+	if req.DisplayName != nil {
+		provider.DisplayName = *req.DisplayName
+	}
+	if req.Description != nil {
+		provider.Description = *req.Description
+	}
+	if req.Issuer != nil {
+		provider.Issuer = *req.Issuer
+	}
+	if req.JwksUri != nil {
+		provider.JwksUri = *req.JwksUri
+	}
+	provider.Metadata.UpdatedAt = now()
+
+	s.writeJSON(w, http.StatusOK, provider)
+}
+
+// deleteIdentityProvider deletes a provider along with its pools and their
+// role bindings.
+func (s *Server) deleteIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.findIdentityProvider(id) == nil {
+		s.notFound(w, "identity provider", id)
+		return
+	}
+
+	s.identityProviders = slices.DeleteFunc(s.identityProviders, func(provider *identityProvider) bool { return provider.Id == id })
+	for _, pool := range filter(s.identityPools, func(pool *identityPool) bool { return pool.provider == id }) {
+		s.removeIdentityPool(pool.Id)
+	}
+	s.writeStatus(w, http.StatusNoContent)
+}
+
+// AddIdentityPool creates an identity pool matching every token of the
+// provider and returns its id.
+func (s *Server) AddIdentityPool(identityProviderId, displayName string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findIdentityProvider(identityProviderId) == nil {
+		return "", fmt.Errorf("identity provider %s not found", identityProviderId)
+	}
+
+	return s.addIdentityPool(identityProviderId, displayName, "", "claims.sub", "true").Id, nil
+}
+
+func (s *Server) addIdentityPool(providerId, displayName, description, identityClaim, filter string) *identityPool {
+	id := s.nextId("pool")
+	pool := &identityPool{
+		ApiVersion:    "iam/v2",
+		Kind:          "IdentityPool",
+		Id:            id,
+		Metadata:      s.newMetadata("/iam/v2/identity-providers/"+providerId+"/identity-pools/"+id, s.crn("/identity-provider="+providerId+"/identity-pool="+id)),
+		DisplayName:   displayName,
+		Description:   description,
+		IdentityClaim: identityClaim,
+		Filter:        filter,
+		State:         "enabled",
+		provider:      providerId,
+	}
+	s.identityPools = append(s.identityPools, pool)
+	return pool
+}
+
+// findIdentityPool looks up the pool of the provider in the path, writing
+// the not found error when either is missing.
+func (s *Server) findIdentityPool(w http.ResponseWriter, r *http.Request) *identityPool {
+	providerId := r.PathValue("provider")
+	if s.findIdentityProvider(providerId) == nil {
+		s.notFound(w, "identity provider", providerId)
+		return nil
+	}
+
+	id := r.PathValue("id")
+	for _, pool := range s.identityPools {
+		if pool.provider == providerId && pool.Id == id {
+			return pool
+		}
+	}
+
+	s.notFound(w, "identity pool", id)
+	return nil
+}
+
+// removeIdentityPool deletes a pool and revokes its role bindings. s.mu must
+// be held.
+func (s *Server) removeIdentityPool(id string) {
+	s.identityPools = slices.DeleteFunc(s.identityPools, func(pool *identityPool) bool { return pool.Id == id })
+	s.roleBindings = slices.DeleteFunc(s.roleBindings, func(rb *roleBinding) bool { return rb.Principal == "User:"+id })
+}
+
+func (s *Server) listIdentityPools(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	providerId := r.PathValue("provider")
+	if s.findIdentityProvider(providerId) == nil {
+		s.notFound(w, "identity provider", providerId)
+		return
+	}
+
+	items := filter(s.identityPools, func(pool *identityPool) bool { return pool.provider == providerId })
+	page, meta, err := paginate(r, items, DefaultPageSize)
+	if err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, list[*identityPool]{ApiVersion: "iam/v2", Kind: "IdentityPoolList", Metadata: meta, Data: nonNil(page)})
+}
+
+func (s *Server) createIdentityPool(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		DisplayName   string `json:"display_name"`
+		Description   string `json:"description"`
+		IdentityClaim string `json:"identity_claim"`
+		Filter        string `json:"filter"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	providerId := r.PathValue("provider")
+	if s.findIdentityProvider(providerId) == nil {
+		s.notFound(w, "identity provider", providerId)
+		return
+	}
+
+	switch {
+	case req.DisplayName == "":
+		s.invalid(w, "display_name is required")
+		return
+	case !strings.HasPrefix(req.IdentityClaim, "claims."):
+		s.invalid(w, fmt.Sprintf("identity_claim %q must start with claims.", req.IdentityClaim))
+		return
+	case req.Filter == "":
+		s.invalid(w, "filter is required")
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, s.addIdentityPool(providerId, req.DisplayName, req.Description, req.IdentityClaim, req.Filter))
+}
+
+func (s *Server) getIdentityPool(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pool := s.findIdentityPool(w, r)
+	if pool == nil {
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, pool)
+}
+
+func (s *Server) updateIdentityPool(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		DisplayName   *string `json:"display_name"`
+		Description   *string `json:"description"`
+		IdentityClaim *string `json:"identity_claim"`
+		Filter        *string `json:"filter"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pool := s.findIdentityPool(w, r)
+	if pool == nil {
+		return
+	}
+
+	if req.DisplayName != nil {
+		pool.DisplayName = *req.DisplayName
+	}
+	if req.Description != nil {
+		pool.Description = *req.Description
+	}
+	if req.IdentityClaim != nil {
+		pool.IdentityClaim = *req.IdentityClaim
+	}
+	if req.Filter != nil {
+		pool.Filter = *req.Filter
+	}
+	pool.Metadata.UpdatedAt = now()
+
+	s.writeJSON(w, http.StatusOK, pool)
+}
+
+func (s *Server) deleteIdentityPool(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pool := s.findIdentityPool(w, r)
+	if pool == nil {
+		return
+	}
+
+	s.removeIdentityPool(pool.Id)
+	s.writeStatus(w, http.StatusNoContent)
+}
+
+func filter[T any](items []T, keep func(T) bool) []T {
+	var kept []T
+	for _, item := range items {
+		if keep(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
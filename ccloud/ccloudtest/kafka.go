@@ -0,0 +1,604 @@
+package ccloudtest
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// Kafka REST v3 error codes.
+const (
+	kafkaErrorBadRequest    = 400
+	kafkaErrorAlreadyExists = 40002
+	kafkaErrorNotFound      = 40403
+)
+
+type related struct {
+	Related string `json:"related"`
+}
+
+type kafkaRestCluster struct {
+	Kind                   string   `json:"kind"`
+	Metadata               metadata `json:"metadata"`
+	ClusterId              string   `json:"cluster_id"`
+	Controller             related  `json:"controller"`
+	Acls                   related  `json:"acls"`
+	Brokers                related  `json:"brokers"`
+	BrokerConfigs          related  `json:"broker_configs"`
+	ConsumerGroups         related  `json:"consumer_groups"`
+	Topics                 related  `json:"topics"`
+	PartitionReassignments related  `json:"partition_reassignments"`
+}
+
+type topic struct {
+	Kind                   string   `json:"kind"`
+	Metadata               metadata `json:"metadata"`
+	ClusterId              string   `json:"cluster_id"`
+	TopicName              string   `json:"topic_name"`
+	IsInternal             bool     `json:"is_internal"`
+	ReplicationFactor      int      `json:"replication_factor"`
+	PartitionsCount        int      `json:"partitions_count"`
+	Partitions             related  `json:"partitions"`
+	Configs                related  `json:"configs"`
+	PartitionReassignments related  `json:"partition_reassignments"`
+
+	configs map[string]string
+}
+
+type topicConfig struct {
+	Kind        string   `json:"kind"`
+	Metadata    metadata `json:"metadata"`
+	ClusterId   string   `json:"cluster_id"`
+	TopicName   string   `json:"topic_name"`
+	Name        string   `json:"name"`
+	Value       string   `json:"value"`
+	IsDefault   bool     `json:"is_default"`
+	IsReadOnly  bool     `json:"is_read_only"`
+	IsSensitive bool     `json:"is_sensitive"`
+	Source      string   `json:"source"`
+	Synonyms    []any    `json:"synonyms"`
+}
+
+type acl struct {
+	Kind         string   `json:"kind"`
+	Metadata     metadata `json:"metadata"`
+	ClusterId    string   `json:"cluster_id"`
+	ResourceType string   `json:"resource_type"`
+	ResourceName string   `json:"resource_name"`
+	PatternType  string   `json:"pattern_type"`
+	Principal    string   `json:"principal"`
+	Host         string   `json:"host"`
+	Operation    string   `json:"operation"`
+	Permission   string   `json:"permission"`
+}
+
+// topicConfigDefaults are the configs of a new topic on Confluent Cloud.
+var topicConfigDefaults = map[string]string{
+	"cleanup.policy":         "delete",
+	"compression.type":       "producer",
+	"delete.retention.ms":    "86400000",
+	"max.message.bytes":      "2097164",
+	"message.timestamp.type": "CreateTime",
+	"min.insync.replicas":    "2",
+	"retention.bytes":        "-1",
+	"retention.ms":           "604800000",
+	"segment.bytes":          "104857600",
+}
+
+func (s *Server) routeKafka(mux *http.ServeMux) {
+	const base = "/kafka/v3/clusters/{cluster}"
+
+	mux.HandleFunc("GET "+base, s.getKafkaRestCluster)
+
+	mux.HandleFunc("GET "+base+"/topics", s.listTopics)
+	mux.HandleFunc("POST "+base+"/topics", s.createTopic)
+	mux.HandleFunc("GET "+base+"/topics/{topic}", s.getTopic)
+	mux.HandleFunc("DELETE "+base+"/topics/{topic}", s.deleteTopic)
+
+	mux.HandleFunc("GET "+base+"/topics/{topic}/configs", s.listTopicConfigs)
+	mux.HandleFunc("POST "+base+"/topics/{topic}/configs:alter", s.alterTopicConfigs)
+	mux.HandleFunc("GET "+base+"/topics/{topic}/configs/{name}", s.getTopicConfig)
+	mux.HandleFunc("PUT "+base+"/topics/{topic}/configs/{name}", s.updateTopicConfig)
+	mux.HandleFunc("DELETE "+base+"/topics/{topic}/configs/{name}", s.resetTopicConfig)
+
+	mux.HandleFunc("GET "+base+"/acls", s.searchAcls)
+	mux.HandleFunc("POST "+base+"/acls", s.createAcl)
+	mux.HandleFunc("POST "+base+"/acls:batch", s.batchCreateAcls)
+	mux.HandleFunc("DELETE "+base+"/acls", s.deleteAcls)
+}
+
+// kafkaError writes the error body of the Kafka REST API.
+func (s *Server) kafkaError(w http.ResponseWriter, status, errorCode int, message string) {
+	s.writeJSON(w, status, map[string]any{"error_code": errorCode, "message": message})
+}
+
+func (s *Server) kafkaUrl(clusterId, path string) string {
+	return s.URL + "/kafka/v3/clusters/" + clusterId + path
+}
+
+func (s *Server) kafkaRestCluster(w http.ResponseWriter, r *http.Request) *kafkaCluster {
+	c := s.findKafkaCluster(r.PathValue("cluster"))
+	if c == nil {
+		s.kafkaError(w, http.StatusNotFound, http.StatusNotFound, fmt.Sprintf("Cluster %s cannot be found.", r.PathValue("cluster")))
+		return nil
+	}
+	return c
+}
+
+func (s *Server) getKafkaRestCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.kafkaRestCluster(w, r)
+	if c == nil {
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, kafkaRestCluster{
+		Kind:                   "KafkaCluster",
+		Metadata:               metadata{Self: s.kafkaUrl(c.Id, ""), ResourceName: "crn:///kafka=" + c.Id},
+		ClusterId:              c.Id,
+		Controller:             related{s.kafkaUrl(c.Id, "/brokers/0")},
+		Acls:                   related{s.kafkaUrl(c.Id, "/acls")},
+		Brokers:                related{s.kafkaUrl(c.Id, "/brokers")},
+		BrokerConfigs:          related{s.kafkaUrl(c.Id, "/broker-configs")},
+		ConsumerGroups:         related{s.kafkaUrl(c.Id, "/consumer-groups")},
+		Topics:                 related{s.kafkaUrl(c.Id, "/topics")},
+		PartitionReassignments: related{s.kafkaUrl(c.Id, "/topics/-/partitions/-/reassignment")},
+	})
+}
+
+func findTopic(c *kafkaCluster, name string) *topic {
+	for _, t := range c.topics {
+		if t.TopicName == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Server) topic(w http.ResponseWriter, r *http.Request) (*kafkaCluster, *topic) {
+	c := s.kafkaRestCluster(w, r)
+	if c == nil {
+		return nil, nil
+	}
+
+	t := findTopic(c, r.PathValue("topic"))
+	if t == nil {
+		s.kafkaError(w, http.StatusNotFound, kafkaErrorNotFound, "This server does not host this topic-partition.")
+		return nil, nil
+	}
+	return c, t
+}
+
+func (s *Server) listTopics(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.kafkaRestCluster(w, r)
+	if c == nil {
+		return
+	}
+
+	page, meta, err := paginate(r, c.topics, 0)
+	if err != nil {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, err.Error())
+		return
+	}
+	meta.Self = s.kafkaUrl(c.Id, "/topics")
+
+	s.writeJSON(w, http.StatusOK, list[*topic]{Kind: "KafkaTopicList", Metadata: meta, Data: nonNil(page)})
+}
+
+func (s *Server) createTopic(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TopicName         string `json:"topic_name"`
+		PartitionsCount   int    `json:"partitions_count"`
+		ReplicationFactor int    `json:"replication_factor"`
+		Configs           []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"configs"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.kafkaRestCluster(w, r)
+	if c == nil {
+		return
+	}
+
+	if req.TopicName == "" {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, "topic_name is required")
+		return
+	}
+	if findTopic(c, req.TopicName) != nil {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorAlreadyExists, fmt.Sprintf("Topic '%s' already exists.", req.TopicName))
+		return
+	}
+
+	t := &topic{
+		Kind:                   "KafkaTopic",
+		Metadata:               metadata{Self: s.kafkaUrl(c.Id, "/topics/"+req.TopicName), ResourceName: "crn:///kafka=" + c.Id + "/topic=" + req.TopicName},
+		ClusterId:              c.Id,
+		TopicName:              req.TopicName,
+		ReplicationFactor:      req.ReplicationFactor,
+		PartitionsCount:        req.PartitionsCount,
+		Partitions:             related{s.kafkaUrl(c.Id, "/topics/"+req.TopicName+"/partitions")},
+		Configs:                related{s.kafkaUrl(c.Id, "/topics/"+req.TopicName+"/configs")},
+		PartitionReassignments: related{s.kafkaUrl(c.Id, "/topics/"+req.TopicName+"/partitions/-/reassignment")},
+		configs:                map[string]string{},
+	}
+	if t.ReplicationFactor == 0 {
+		t.ReplicationFactor = 3
+	}
+	if t.PartitionsCount == 0 {
+		t.PartitionsCount = 6
+	}
+
+	for _, config := range req.Configs {
+		if _, ok := topicConfigDefaults[config.Name]; !ok {
+			s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, fmt.Sprintf("Unknown topic config name: %s", config.Name))
+			return
+		}
+		t.configs[config.Name] = config.Value
+	}
+
+	c.topics = append(c.topics, t)
+	s.writeJSON(w, http.StatusCreated, t)
+}
+
+func (s *Server) getTopic(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, t := s.topic(w, r); t != nil {
+		s.writeJSON(w, http.StatusOK, t)
+	}
+}
+
+func (s *Server) deleteTopic(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, t := s.topic(w, r)
+	if t == nil {
+		return
+	}
+
+	c.topics = slices.DeleteFunc(c.topics, func(item *topic) bool { return item == t })
+	s.writeStatus(w, http.StatusNoContent)
+}
+
+func (s *Server) topicConfig(t *topic, name string) topicConfig {
+	config := topicConfig{
+		Kind:      "KafkaTopicConfig",
+		Metadata:  metadata{Self: t.Configs.Related + "/" + name, ResourceName: t.Metadata.ResourceName + "/config=" + name},
+		ClusterId: t.ClusterId,
+		TopicName: t.TopicName,
+		Name:      name,
+		Value:     topicConfigDefaults[name],
+		IsDefault: true,
+		Source:    "DEFAULT_CONFIG",
+		Synonyms:  []any{},
+	}
+
+	if value, ok := t.configs[name]; ok {
+		config.Value = value
+		config.IsDefault = false
+		config.Source = "DYNAMIC_TOPIC_CONFIG"
+	}
+
+	return config
+}
+
+func (s *Server) listTopicConfigs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, t := s.topic(w, r)
+	if t == nil {
+		return
+	}
+
+	var configs []topicConfig
+	for _, name := range slices.Sorted(maps.Keys(topicConfigDefaults)) {
+		configs = append(configs, s.topicConfig(t, name))
+	}
+
+	page, meta, err := paginate(r, configs, 0)
+	if err != nil {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, err.Error())
+		return
+	}
+	meta.Self = t.Configs.Related
+
+	s.writeJSON(w, http.StatusOK, list[topicConfig]{Kind: "KafkaTopicConfigList", Metadata: meta, Data: nonNil(page)})
+}
+
+// topicConfigName checks the config name of the request path.
+func (s *Server) topicConfigName(w http.ResponseWriter, r *http.Request, t *topic) (string, bool) {
+	name := r.PathValue("name")
+	if _, ok := topicConfigDefaults[name]; !ok {
+		s.kafkaError(w, http.StatusNotFound, http.StatusNotFound, fmt.Sprintf("Config %s cannot be found for TOPIC %s in cluster %s.", name, t.TopicName, t.ClusterId))
+		return "", false
+	}
+	return name, true
+}
+
+func (s *Server) getTopicConfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, t := s.topic(w, r)
+	if t == nil {
+		return
+	}
+
+	if name, ok := s.topicConfigName(w, r, t); ok {
+		s.writeJSON(w, http.StatusOK, s.topicConfig(t, name))
+	}
+}
+
+func (s *Server) updateTopicConfig(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Value string `json:"value"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, t := s.topic(w, r)
+	if t == nil {
+		return
+	}
+
+	if name, ok := s.topicConfigName(w, r, t); ok {
+		t.configs[name] = req.Value
+		s.writeStatus(w, http.StatusNoContent)
+	}
+}
+
+func (s *Server) resetTopicConfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, t := s.topic(w, r)
+	if t == nil {
+		return
+	}
+
+	if name, ok := s.topicConfigName(w, r, t); ok {
+		delete(t.configs, name)
+		s.writeStatus(w, http.StatusNoContent)
+	}
+}
+
+// alterTopicConfigs applies a batch of SET and DELETE operations atomically.
+func (s *Server) alterTopicConfigs(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Data []struct {
+			Name      string `json:"name"`
+			Value     string `json:"value"`
+			Operation string `json:"operation"`
+		} `json:"data"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, t := s.topic(w, r)
+	if t == nil {
+		return
+	}
+
+	for _, item := range req.Data {
+		if _, ok := topicConfigDefaults[item.Name]; !ok {
+			s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, fmt.Sprintf("Unknown topic config name: %s", item.Name))
+			return
+		}
+		if item.Operation != "" && item.Operation != "SET" && item.Operation != "DELETE" {
+			s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, fmt.Sprintf("Invalid operation %s", item.Operation))
+			return
+		}
+	}
+
+	for _, item := range req.Data {
+		if item.Operation == "DELETE" {
+			delete(t.configs, item.Name)
+		} else {
+			t.configs[item.Name] = item.Value
+		}
+	}
+
+	s.writeStatus(w, http.StatusNoContent)
+}
+
+// aclFilter matches ACLs against the query of a search or delete request.
+// Missing fields and ANY match everything.
+func aclFilter(query url.Values) func(*acl) bool {
+	matches := func(field, value string) bool {
+		filter := query.Get(field)
+		return filter == "" || filter == "ANY" || filter == value
+	}
+
+	return func(a *acl) bool {
+		return matches("resource_type", a.ResourceType) &&
+			matches("resource_name", a.ResourceName) &&
+			matches("pattern_type", a.PatternType) &&
+			matches("principal", a.Principal) &&
+			matches("host", a.Host) &&
+			matches("operation", a.Operation) &&
+			matches("permission", a.Permission)
+	}
+}
+
+func (s *Server) searchAcls(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.kafkaRestCluster(w, r)
+	if c == nil {
+		return
+	}
+
+	page, meta, err := paginate(r, filter(c.acls, aclFilter(r.URL.Query())), 0)
+	if err != nil {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, err.Error())
+		return
+	}
+	meta.Self = requestUrl(r, r.URL)
+
+	s.writeJSON(w, http.StatusOK, list[*acl]{Kind: "KafkaAclDataList", Metadata: meta, Data: nonNil(page)})
+}
+
+type aclCreateReq struct {
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	PatternType  string `json:"pattern_type"`
+	Principal    string `json:"principal"`
+	Host         string `json:"host"`
+	Operation    string `json:"operation"`
+	Permission   string `json:"permission"`
+}
+
+func (req *aclCreateReq) validate() error {
+	switch {
+	case !slices.Contains([]string{"TOPIC", "GROUP", "CLUSTER", "TRANSACTIONAL_ID", "DELEGATION_TOKEN"}, req.ResourceType):
+		return fmt.Errorf("invalid resource_type %q", req.ResourceType)
+	case req.ResourceName == "":
+		return fmt.Errorf("resource_name is required")
+	case req.PatternType != "LITERAL" && req.PatternType != "PREFIXED":
+		return fmt.Errorf("invalid pattern_type %q", req.PatternType)
+	case req.Principal == "":
+		return fmt.Errorf("principal is required")
+	case req.Host == "":
+		return fmt.Errorf("host is required")
+	case req.Operation == "" || req.Operation == "ANY" || req.Operation == "UNKNOWN":
+		return fmt.Errorf("invalid operation %q", req.Operation)
+	case req.Permission != "ALLOW" && req.Permission != "DENY":
+		return fmt.Errorf("invalid permission %q", req.Permission)
+	}
+	return nil
+}
+
+// addAcl creates an ACL unless it already exists; creating ACLs is idempotent.
+func (s *Server) addAcl(c *kafkaCluster, req aclCreateReq) {
+	a := &acl{
+		Kind:         "KafkaAcl",
+		ClusterId:    c.Id,
+		ResourceType: req.ResourceType,
+		ResourceName: req.ResourceName,
+		PatternType:  req.PatternType,
+		Principal:    req.Principal,
+		Host:         req.Host,
+		Operation:    req.Operation,
+		Permission:   req.Permission,
+	}
+
+	query := url.Values{}
+	query.Set("resource_type", a.ResourceType)
+	query.Set("resource_name", a.ResourceName)
+	query.Set("pattern_type", a.PatternType)
+	query.Set("principal", a.Principal)
+	query.Set("host", a.Host)
+	query.Set("operation", a.Operation)
+	query.Set("permission", a.Permission)
+	a.Metadata.Self = s.kafkaUrl(c.Id, "/acls?"+query.Encode())
+
+	if slices.ContainsFunc(c.acls, func(existing *acl) bool { return existing.Metadata.Self == a.Metadata.Self }) {
+		return
+	}
+	c.acls = append(c.acls, a)
+}
+
+func (s *Server) createAcl(w http.ResponseWriter, r *http.Request) {
+	var req aclCreateReq
+	if err := decode(r, &req); err != nil {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.kafkaRestCluster(w, r)
+	if c == nil {
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, err.Error())
+		return
+	}
+
+	s.addAcl(c, req)
+	s.writeStatus(w, http.StatusCreated)
+}
+
+func (s *Server) batchCreateAcls(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Data []aclCreateReq `json:"data"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.kafkaRestCluster(w, r)
+	if c == nil {
+		return
+	}
+
+	for i, item := range req.Data {
+		if err := item.validate(); err != nil {
+			s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, "data["+strconv.Itoa(i)+"]: "+err.Error())
+			return
+		}
+	}
+
+	for _, item := range req.Data {
+		s.addAcl(c, item)
+	}
+	s.writeStatus(w, http.StatusCreated)
+}
+
+// deleteAcls deletes every ACL matching the filter and returns them.
+func (s *Server) deleteAcls(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.kafkaRestCluster(w, r)
+	if c == nil {
+		return
+	}
+
+	if r.URL.Query().Get("resource_type") == "" {
+		s.kafkaError(w, http.StatusBadRequest, kafkaErrorBadRequest, "resource_type is required")
+		return
+	}
+
+	deleted := filter(c.acls, aclFilter(r.URL.Query()))
+	c.acls = slices.DeleteFunc(c.acls, func(a *acl) bool { return slices.Contains(deleted, a) })
+
+	s.writeJSON(w, http.StatusOK, list[*acl]{Kind: "KafkaAclDataList", Metadata: listMetadata{Self: requestUrl(r, r.URL)}, Data: nonNil(deleted)})
+}
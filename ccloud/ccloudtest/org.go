@@ -0,0 +1,157 @@
+package ccloudtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+type environment struct {
+	ApiVersion  string   `json:"api_version"`
+	Kind        string   `json:"kind"`
+	Id          string   `json:"id"`
+	Metadata    metadata `json:"metadata"`
+	DisplayName string   `json:"display_name"`
+}
+
+func (s *Server) routeOrg(mux *http.ServeMux) {
+	mux.HandleFunc("GET /org/v2/environments", s.listEnvironments)
+	mux.HandleFunc("POST /org/v2/environments", s.createEnvironment)
+	mux.HandleFunc("GET /org/v2/environments/{id}", s.getEnvironment)
+	mux.HandleFunc("PATCH /org/v2/environments/{id}", s.updateEnvironment)
+	mux.HandleFunc("DELETE /org/v2/environments/{id}", s.deleteEnvironment)
+}
+
+// AddEnvironment creates an environment and returns its id.
+func (s *Server) AddEnvironment(displayName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addEnvironment(displayName).Id
+}
+
+func (s *Server) addEnvironment(displayName string) *environment {
+	id := s.nextId("env")
+	env := &environment{
+		ApiVersion:  "org/v2",
+		Kind:        "Environment",
+		Id:          id,
+		Metadata:    s.newMetadata("/org/v2/environments/"+id, s.crn("/environment="+id)),
+		DisplayName: displayName,
+	}
+	s.environments = append(s.environments, env)
+	return env
+}
+
+func (s *Server) findEnvironment(id string) *environment {
+	for _, env := range s.environments {
+		if env.Id == id {
+			return env
+		}
+	}
+	return nil
+}
+
+func (s *Server) listEnvironments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	page, meta, err := paginate(r, s.environments, DefaultPageSize)
+	if err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, list[*environment]{ApiVersion: "org/v2", Kind: "EnvironmentList", Metadata: meta, Data: nonNil(page)})
+}
+
+func (s *Server) createEnvironment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		DisplayName string `json:"display_name"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.DisplayName == "" {
+		s.invalid(w, "display_name is required")
+		return
+	}
+
+	if slices.ContainsFunc(s.environments, func(env *environment) bool { return env.DisplayName == req.DisplayName }) {
+		s.error(w, http.StatusConflict, "already_exists", fmt.Sprintf("Environment name %q is already in use.", req.DisplayName))
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, s.addEnvironment(req.DisplayName))
+}
+
+func (s *Server) getEnvironment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	env := s.findEnvironment(r.PathValue("id"))
+	if env == nil {
+		s.notFound(w, "environment", r.PathValue("id"))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, env)
+}
+
+func (s *Server) updateEnvironment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		DisplayName string `json:"display_name"`
+	}
+	if err := decode(r, &req); err != nil {
+		s.invalid(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	env := s.findEnvironment(r.PathValue("id"))
+	if env == nil {
+		s.notFound(w, "environment", r.PathValue("id"))
+		return
+	}
+
+	if req.DisplayName != "" {
+		env.DisplayName = req.DisplayName
+		env.Metadata.UpdatedAt = now()
+	}
+
+	s.writeJSON(w, http.StatusOK, env)
+}
+
+func (s *Server) deleteEnvironment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.findEnvironment(id) == nil {
+		s.notFound(w, "environment", id)
+		return
+	}
+
+	if slices.ContainsFunc(s.clusters, func(c *kafkaCluster) bool { return c.Spec.Environment.Id == id }) {
+		s.error(w, http.StatusConflict, "resource_in_use", fmt.Sprintf("Environment %s still has Kafka clusters.", id))
+		return
+	}
+
+	s.environments = slices.DeleteFunc(s.environments, func(env *environment) bool { return env.Id == id })
+	s.writeStatus(w, http.StatusNoContent)
+}
+
+// nonNil keeps empty lists encoded as [] rather than null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,310 @@
+// Package ccloudtest provides an in-memory fake of the Confluent Cloud APIs
+// for testing code built on this library without an organization.
+//
+// The server emulates org/v2 environments, iam/v2 service accounts, users,
+// invitations, API keys, role bindings, identity providers and identity
+// pools, cmk/v2 clusters, connect/v1
+// connectors and the Kafka REST v3 topics, topic configs and ACLs of the
+// clusters it hosts:
+//
+//	srv := ccloudtest.NewServer()
+//	defer srv.Close()
+//
+//	c := srv.Client()
+//	env, err := c.CreateEnvironment(&ccloud.EnvironmentCreateReq{DisplayName: "dev"})
+package ccloudtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/cluster"
+)
+
+const (
+	// ApiKey and ApiSecret are the credentials used by Client and
+	// ClusterClient. The server accepts any credential but rejects requests
+	// without one.
+	ApiKey    = "CCLOUDTESTKEY"
+	ApiSecret = "ccloudtest-secret"
+
+	OrganizationId = "00000000-0000-0000-0000-000000000000"
+
+	// DefaultPageSize and MaxPageSize mirror the limits of the v2 APIs.
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+type Option func(*Server)
+
+// WithProvisioningPolls sets how many reads report a new cluster as
+// PROVISIONING, or a new connector as PROVISIONING, before it becomes
+// PROVISIONED or RUNNING. The default is 1; 0 makes them ready right away.
+func WithProvisioningPolls(polls int) Option {
+	return func(s *Server) {
+		s.provisioningPolls = polls
+	}
+}
+
+// Server is a running fake. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	provisioningPolls int
+	requests          atomic.Int64
+
+	mu                sync.Mutex
+	seq               map[string]int
+	environments      []*environment
+	serviceAccounts   []*serviceAccount
+	apiKeys           []*apiKey
+	roleBindings      []*roleBinding
+	users             []*user
+	invitations       []*invitation
+	identityProviders []*identityProvider
+	identityPools     []*identityPool
+	clusters          []*kafkaCluster
+	connectors        []*connector
+}
+
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		provisioningPolls: 1,
+		seq:               map[string]int{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	s.routeOrg(mux)
+	s.routeIam(mux)
+	s.routeCmk(mux)
+	s.routeConnect(mux)
+	s.routeKafka(mux)
+
+	s.Server = httptest.NewServer(s.authenticate(mux))
+
+	return s
+}
+
+// Client returns a ConfluentClient talking to the server.
+func (s *Server) Client(opts ...client.Option) *ccloud.ConfluentClient {
+	return ccloud.NewClient(opts...).WithBaseUrl(s.URL).WithAuth(ccloud.NewBasicAuth(ApiKey, ApiSecret))
+}
+
+// ClusterClient returns a client for the Kafka REST API of a cluster hosted
+// by the server.
+func (s *Server) ClusterClient(clusterId string, opts ...client.Option) (*cluster.ConfluentClusterClient, error) {
+	return cluster.NewClusterClient(ccloud.NewBasicAuth(ApiKey, ApiSecret), clusterId, s.URL, opts...)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch apiOf(r) {
+		case apiKafka:
+			s.kafkaError(w, http.StatusUnauthorized, 40101, "Unauthorized")
+		case apiConnect:
+			s.connectError(w, http.StatusUnauthorized, "Unauthorized")
+		default:
+			s.error(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		}
+	})
+}
+
+// nextId returns sequential ids such as env-000001, so that tests can rely
+// on them. s.mu must be held.
+func (s *Server) nextId(prefix string) string {
+	s.seq[prefix]++
+	return fmt.Sprintf("%s-%06s", prefix, strconv.FormatInt(int64(s.seq[prefix]), 36))
+}
+
+func (s *Server) requestId() string {
+	return fmt.Sprintf("ccloudtest-%08d", s.requests.Add(1))
+}
+
+func (s *Server) crn(path string) string {
+	return "crn://confluent.cloud/organization=" + OrganizationId + path
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+type metadata struct {
+	Self         string `json:"self"`
+	ResourceName string `json:"resource_name,omitempty"`
+	CreatedAt    string `json:"created_at,omitempty"`
+	UpdatedAt    string `json:"updated_at,omitempty"`
+}
+
+func (s *Server) newMetadata(path, resourceName string) metadata {
+	ts := now()
+	return metadata{
+		Self:         s.URL + path,
+		ResourceName: resourceName,
+		CreatedAt:    ts,
+		UpdatedAt:    ts,
+	}
+}
+
+// objectRef is the reference to a related object used across the v2 APIs.
+type objectRef struct {
+	Id           string `json:"id"`
+	Environment  string `json:"environment,omitempty"`
+	Related      string `json:"related,omitempty"`
+	ResourceName string `json:"resource_name,omitempty"`
+	ApiVersion   string `json:"api_version,omitempty"`
+	Kind         string `json:"kind,omitempty"`
+}
+
+type listMetadata struct {
+	Self      string `json:"self,omitempty"`
+	First     string `json:"first,omitempty"`
+	Next      string `json:"next,omitempty"`
+	TotalSize int    `json:"total_size,omitempty"`
+}
+
+type list[T any] struct {
+	ApiVersion string       `json:"api_version,omitempty"`
+	Kind       string       `json:"kind"`
+	Metadata   listMetadata `json:"metadata"`
+	Data       []T          `json:"data"`
+}
+
+// paginate cuts the page requested by page_size and page_token out of items.
+// A defaultSize of 0 returns every item when no page size is requested, as
+// the Kafka REST API does.
+func paginate[T any](r *http.Request, items []T, defaultSize int) ([]T, listMetadata, error) {
+	query := r.URL.Query()
+
+	size := defaultSize
+	if value := query.Get("page_size"); value != "" {
+		var err error
+		size, err = strconv.Atoi(value)
+		if err != nil || size < 1 || size > MaxPageSize {
+			return nil, listMetadata{}, fmt.Errorf("page_size must be between 1 and %d", MaxPageSize)
+		}
+	}
+
+	offset := 0
+	if token := query.Get("page_token"); token != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(token)
+		if err == nil {
+			offset, err = strconv.Atoi(string(decoded))
+		}
+		if err != nil || offset < 0 || offset > len(items) {
+			return nil, listMetadata{}, fmt.Errorf("invalid page_token %q", token)
+		}
+	}
+
+	first := *r.URL
+	firstQuery := first.Query()
+	firstQuery.Del("page_token")
+	first.RawQuery = firstQuery.Encode()
+
+	meta := listMetadata{
+		First:     requestUrl(r, &first),
+		TotalSize: len(items),
+	}
+
+	end := len(items)
+	if size > 0 && offset+size < len(items) {
+		end = offset + size
+
+		next := first
+		nextQuery := next.Query()
+		nextQuery.Set("page_token", base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end))))
+		next.RawQuery = nextQuery.Encode()
+		meta.Next = requestUrl(r, &next)
+	}
+
+	return items[offset:end], meta, nil
+}
+
+func requestUrl(r *http.Request, u *url.URL) string {
+	return "http://" + r.Host + u.RequestURI()
+}
+
+func decode(r *http.Request, v any) error {
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("malformed request body: %s", err)
+	}
+	return nil
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-Id", s.requestId())
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func (s *Server) writeStatus(w http.ResponseWriter, status int) {
+	w.Header().Set("X-Request-Id", s.requestId())
+	w.WriteHeader(status)
+}
+
+// error writes the JSON:API error body of the v2 APIs.
+func (s *Server) error(w http.ResponseWriter, status int, code, detail string) {
+	type apiError struct {
+		Id     string `json:"id"`
+		Status string `json:"status"`
+		Code   string `json:"code,omitempty"`
+		Detail string `json:"detail"`
+	}
+
+	requestId := s.requestId()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-Id", requestId)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string][]apiError{
+		"errors": {{Id: requestId, Status: strconv.Itoa(status), Code: code, Detail: detail}},
+	})
+}
+
+func (s *Server) notFound(w http.ResponseWriter, kind, id string) {
+	s.error(w, http.StatusNotFound, "resource_not_found", fmt.Sprintf("The %s %s was not found.", kind, id))
+}
+
+func (s *Server) invalid(w http.ResponseWriter, detail string) {
+	s.error(w, http.StatusBadRequest, "invalid_input", detail)
+}
+
+type apiFamily int
+
+const (
+	apiV2 apiFamily = iota
+	apiKafka
+	apiConnect
+)
+
+func apiOf(r *http.Request) apiFamily {
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/kafka/v3/"):
+		return apiKafka
+	case strings.HasPrefix(r.URL.Path, "/connect/v1/"):
+		return apiConnect
+	}
+	return apiV2
+}
@@ -0,0 +1,397 @@
+package ccloudtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
+	"github.com/electric-saw/ccloud-client-go/ccloud/cluster"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironments(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	c := srv.Client()
+	for i := range 12 {
+		_, err := c.CreateEnvironment(&ccloud.EnvironmentCreateReq{DisplayName: fmt.Sprintf("env %d", i)})
+		require.NoError(t, err)
+	}
+
+	page, err := c.ListEnvironments(nil)
+	require.NoError(t, err)
+	assert.Len(t, page.Data, ccloudtest.DefaultPageSize)
+	assert.NotEmpty(t, page.GetPageNextToken())
+
+	environments, err := c.ListAllEnvironments(context.Background(), &common.PaginationOptions{PageSize: 5})
+	require.NoError(t, err)
+	assert.Len(t, environments, 12)
+	assert.Equal(t, "env-000001", environments[0].Id)
+
+	updated, err := c.UpdateEnvironment("env-000001", &ccloud.EnvironmentUpdateReq{DisplayName: "renamed"})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", updated.DisplayName)
+
+	_, err = c.CreateEnvironment(&ccloud.EnvironmentCreateReq{DisplayName: "renamed"})
+	assert.ErrorIs(t, err, common.ErrConflict)
+
+	require.NoError(t, c.DeleteEnvironment("env-000001"))
+	_, err = c.GetEnvironment("env-000001")
+	assert.ErrorIs(t, err, common.ErrNotFound)
+
+	var apiErr *common.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "resource_not_found", apiErr.Code)
+	assert.NotEmpty(t, apiErr.RequestId)
+
+	_, err = c.ListEnvironments(&common.PaginationOptions{PageSize: 500})
+	assert.ErrorIs(t, err, common.ErrValidation)
+
+	_, err = ccloud.NewClient().WithBaseUrl(srv.URL).ListEnvironments(nil)
+	assert.ErrorIs(t, err, common.ErrUnauthorized)
+}
+
+func TestServiceAccountsApiKeysAndRoleBindings(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	c := srv.Client()
+	sa, err := c.CreateServiceAccount(&ccloud.ServiceAccountCreateReq{DisplayName: "ci", Description: "pipelines"})
+	require.NoError(t, err)
+	assert.Equal(t, "sa-000001", sa.Id)
+
+	_, err = c.CreateServiceAccount(&ccloud.ServiceAccountCreateReq{DisplayName: "ci"})
+	assert.ErrorIs(t, err, common.ErrConflict)
+
+	envId := srv.AddEnvironment("prod")
+	clusterId, err := srv.AddKafkaCluster(envId, "orders")
+	require.NoError(t, err)
+
+	created, err := c.CreateApiKey(&ccloud.ApiKeyCreateReq{
+		DisplayName: "ci key",
+		Owner:       ccloud.ApiKeyCommonReq{Id: sa.Id},
+		Resource:    ccloud.ApiKeyCommonReq{Id: clusterId, Environment: envId},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Spec.Secret)
+
+	apiKey, err := c.GetApiKey(created.Id)
+	require.NoError(t, err)
+	assert.Empty(t, apiKey.Spec.Secret, "the secret is only returned on creation")
+	assert.Equal(t, sa.Id, apiKey.Spec.Owner.Id)
+	assert.Equal(t, clusterId, apiKey.Spec.Resource.Id)
+
+	keys, err := c.ListApiKeys(&ccloud.ApiKeyListOptions{Owner: sa.Id})
+	require.NoError(t, err)
+	assert.Len(t, keys.Data, 1)
+
+	_, err = c.CreateApiKey(&ccloud.ApiKeyCreateReq{Owner: ccloud.ApiKeyCommonReq{Id: "sa-missing"}})
+	assert.ErrorIs(t, err, common.ErrValidation)
+
+	scope := "crn://confluent.cloud/organization=" + ccloudtest.OrganizationId + "/environment=" + envId
+	binding, err := c.CreateRoleBinding(&ccloud.RoleBindingCreateReq{
+		Principal:  "User:" + sa.Id,
+		RoleName:   "CloudClusterAdmin",
+		CrnPattern: scope + "/cloud-cluster=" + clusterId,
+	})
+	require.NoError(t, err)
+
+	bindings, err := c.ListRoleBindings(&ccloud.ListRoleBindingsQuery{CrnPattern: scope})
+	require.NoError(t, err)
+	require.Len(t, bindings.Data, 1)
+	assert.Equal(t, binding.Id, bindings.Data[0].Id)
+
+	_, err = c.ListRoleBindings(&ccloud.ListRoleBindingsQuery{Principal: "User:" + sa.Id})
+	assert.ErrorIs(t, err, common.ErrValidation, "crn_pattern is required")
+
+	require.NoError(t, c.DeleteServiceAccount(sa.Id))
+	_, err = c.GetApiKey(created.Id)
+	assert.ErrorIs(t, err, common.ErrNotFound, "deleting a service account revokes its keys")
+}
+
+func TestUsersAndInvitations(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	c := srv.Client()
+	invitation, err := c.CreateInvitation(&ccloud.InvitationCreateReq{Email: "jane@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, ccloud.InvitationStatusSent, invitation.Status)
+	assert.Equal(t, ccloud.AuthTypeLocal, invitation.AuthType)
+
+	user, err := c.GetUser(invitation.User.Id)
+	require.NoError(t, err, "the invited user exists before accepting")
+	assert.Equal(t, "jane@example.com", user.Email)
+
+	_, err = c.CreateInvitation(&ccloud.InvitationCreateReq{Email: "JANE@example.com"})
+	assert.ErrorIs(t, err, common.ErrConflict)
+
+	require.NoError(t, srv.AcceptInvitation(invitation.Id))
+	assert.Error(t, srv.AcceptInvitation(invitation.Id))
+
+	_, err = c.UpdateUser(user.Id, &ccloud.UserUpdateReq{FullName: "Jane Doe"})
+	require.NoError(t, err)
+
+	users, err := c.ListAllUsers(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "Jane Doe", users[0].FullName)
+
+	require.NoError(t, c.DeleteUser(user.Id))
+	_, err = c.GetInvitation(invitation.Id)
+	assert.ErrorIs(t, err, common.ErrNotFound, "deleting a user removes its invitations")
+}
+
+func TestIdentityProvidersAndPools(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	c := srv.Client()
+	provider, err := c.CreateIdentityProvider(&ccloud.IdentityProviderCreateReq{
+		DisplayName: "okta",
+		Issuer:      "https://example.okta.com",
+		JwksUri:     "https://example.okta.com/keys",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "op-000001", provider.Id)
+
+	_, err = c.CreateIdentityProvider(&ccloud.IdentityProviderCreateReq{DisplayName: "plain", Issuer: "http://example.com", JwksUri: "https://example.com/keys"})
+	assert.ErrorIs(t, err, common.ErrValidation)
+
+	otherId := srv.AddIdentityProvider("azure", "https://login.microsoftonline.com/tenant")
+	providers, err := c.ListAllIdentityProviders(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, providers, 2)
+
+	provider, err = c.UpdateIdentityProvider(provider.Id, &ccloud.IdentityProviderUpdateReq{Description: "workforce"})
+	require.NoError(t, err)
+	assert.Equal(t, "workforce", provider.Description)
+	assert.Equal(t, "okta", provider.DisplayName)
+
+	pool, err := c.CreateIdentityPool(provider.Id, &ccloud.IdentityPoolCreateReq{
+		DisplayName:   "ci",
+		IdentityClaim: "claims.sub",
+		Filter:        `claims.aud == "confluent"`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "crn://confluent.cloud/organization="+ccloudtest.OrganizationId+"/identity-provider="+provider.Id+"/identity-pool="+pool.Id, *pool.Metadata.ResourceName)
+
+	_, err = c.CreateIdentityPool(provider.Id, &ccloud.IdentityPoolCreateReq{DisplayName: "bad", IdentityClaim: "sub", Filter: "true"})
+	assert.ErrorIs(t, err, common.ErrValidation)
+	_, err = c.CreateIdentityPool("op-missing", &ccloud.IdentityPoolCreateReq{DisplayName: "ci", IdentityClaim: "claims.sub", Filter: "true"})
+	assert.ErrorIs(t, err, common.ErrNotFound)
+
+	otherPoolId, err := srv.AddIdentityPool(otherId, "deploy")
+	require.NoError(t, err)
+	_, err = c.GetIdentityPool(provider.Id, otherPoolId)
+	assert.ErrorIs(t, err, common.ErrNotFound, "pools are only found under their provider")
+
+	pool, err = c.UpdateIdentityPool(provider.Id, pool.Id, &ccloud.IdentityPoolUpdateReq{Filter: "true"})
+	require.NoError(t, err)
+	assert.Equal(t, "true", pool.Filter)
+
+	pools, err := c.ListAllIdentityPools(context.Background(), provider.Id, nil)
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	assert.Equal(t, pool.Id, pools[0].Id)
+
+	_, err = c.CreateRoleBinding(ccloud.NewRoleBindingCreateReq(pool.Principal(), "MetricsViewer", crn.New(ccloudtest.OrganizationId)))
+	require.NoError(t, err)
+
+	require.NoError(t, c.DeleteIdentityProvider(provider.Id))
+	_, err = c.GetIdentityProvider(provider.Id)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	_, err = c.ListIdentityPools(provider.Id, nil)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+
+	bindings, err := c.ListAllRoleBindings(context.Background(), &ccloud.ListRoleBindingsQuery{
+		Principal:  pool.Principal(),
+		CrnPattern: crn.New(ccloudtest.OrganizationId).String(),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, bindings, "deleting the provider revokes the bindings of its pools")
+
+	require.NoError(t, c.DeleteIdentityPool(otherId, otherPoolId))
+	_, err = c.GetIdentityPool(otherId, otherPoolId)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
+
+func TestKafkaClusterPhases(t *testing.T) {
+	srv := ccloudtest.NewServer(ccloudtest.WithProvisioningPolls(2))
+	defer srv.Close()
+
+	c := srv.Client()
+	envId := srv.AddEnvironment("dev")
+
+	create := &ccloud.KafkaClusterCreateReq{DisplayName: "orders", Cloud: common.CloudProviderAWS, Region: "us-east-1"}
+	create.Config.Kind = ccloud.KafkaClusterKindBasic
+	create.Environment.Id = envId
+
+	created, err := c.CreateKafkaCluster(create)
+	require.NoError(t, err)
+	assert.Equal(t, ccloudtest.PhaseProvisioning, created.Status.Phase)
+	assert.Equal(t, srv.URL, created.Spec.HttpEndpoint)
+
+	var phases []string
+	for range 3 {
+		kafkaCluster, err := c.GetKafkaCluster(created.Id, &ccloud.KafkaClusterListOptions{EnvironmentId: envId})
+		require.NoError(t, err)
+		phases = append(phases, kafkaCluster.Status.Phase)
+	}
+	assert.Equal(t, []string{ccloudtest.PhaseProvisioning, ccloudtest.PhaseProvisioning, ccloudtest.PhaseProvisioned}, phases)
+
+	_, err = c.GetKafkaCluster(created.Id, nil)
+	assert.ErrorIs(t, err, common.ErrValidation, "the environment is required")
+
+	require.NoError(t, srv.SetKafkaClusterPhase(created.Id, ccloudtest.PhaseFailed))
+	kafkaCluster, err := c.GetKafkaCluster(created.Id, &ccloud.KafkaClusterListOptions{EnvironmentId: envId})
+	require.NoError(t, err)
+	assert.Equal(t, ccloudtest.PhaseFailed, kafkaCluster.Status.Phase)
+
+	assert.ErrorIs(t, c.DeleteEnvironment(envId), common.ErrConflict)
+	require.NoError(t, c.DeleteKafkaCluster(created.Id, ccloud.KafkaClusterListOptions{EnvironmentId: envId}))
+	require.NoError(t, c.DeleteEnvironment(envId))
+}
+
+func TestConnectors(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	c := srv.Client()
+	envId := srv.AddEnvironment("dev")
+	clusterId, err := srv.AddKafkaCluster(envId, "orders")
+	require.NoError(t, err)
+
+	_, err = c.CreateConnector(envId, clusterId, "s3-sink", &ccloud.S3SinkConnectorConfig{
+		Bucket:         "orders-archive",
+		Topics:         "orders",
+		KafkaApiKey:    "KEY",
+		KafkaApiSecret: "do-not-echo",
+		TasksMax:       "2",
+	})
+	require.NoError(t, err)
+
+	_, err = c.CreateConnector(envId, clusterId, "s3-sink", &ccloud.S3SinkConnectorConfig{})
+	assert.ErrorIs(t, err, common.ErrConflict)
+
+	connector, err := c.GetConnector(envId, clusterId, "s3-sink")
+	require.NoError(t, err)
+	assert.Equal(t, "sink", connector.Type)
+	assert.NotEqual(t, "do-not-echo", connector.Config["kafka.api.secret"])
+
+	status, err := c.GetConnectorStatus(envId, clusterId, "s3-sink")
+	require.NoError(t, err)
+	assert.Equal(t, ccloudtest.ConnectorStateProvisioning, status.Connector.State)
+
+	status, err = c.GetConnectorStatus(envId, clusterId, "s3-sink")
+	require.NoError(t, err)
+	assert.Equal(t, ccloudtest.ConnectorStateRunning, status.Connector.State)
+	assert.Len(t, status.Tasks, 2)
+
+	require.NoError(t, c.PauseConnector(envId, clusterId, "s3-sink"))
+	expanded, err := c.GetConnectorWithExpansions(envId, clusterId, "s3-sink", "status", "id")
+	require.NoError(t, err)
+	assert.Equal(t, ccloudtest.ConnectorStatePaused, expanded.Status.Connector.State)
+	assert.Equal(t, "lcc-000001", expanded.Id.Id)
+
+	require.NoError(t, srv.SetConnectorState(envId, clusterId, "s3-sink", ccloudtest.ConnectorStateFailed, "boom"))
+	status, err = c.GetConnectorStatus(envId, clusterId, "s3-sink")
+	require.NoError(t, err)
+	assert.Equal(t, "boom", status.Connector.Trace)
+
+	require.NoError(t, c.DeleteConnector(envId, clusterId, "s3-sink"))
+	_, err = c.GetConnector(envId, clusterId, "s3-sink")
+	assert.ErrorIs(t, err, common.ErrNotFound)
+
+	_, err = c.ListConnectors(envId, "lkc-missing")
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
+
+func TestKafkaRest(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	c := srv.Client()
+	envId := srv.AddEnvironment("dev")
+	clusterId, err := srv.AddKafkaCluster(envId, "orders")
+	require.NoError(t, err)
+
+	kafkaCluster, err := c.GetKafkaCluster(clusterId, &ccloud.KafkaClusterListOptions{EnvironmentId: envId})
+	require.NoError(t, err)
+
+	clusterClient, err := c.NewClusterClient(kafkaCluster, ccloud.NewBasicAuth(ccloudtest.ApiKey, ccloudtest.ApiSecret))
+	require.NoError(t, err)
+
+	topic, err := clusterClient.CreateTopic(&cluster.TopicCreateReq{
+		TopicName: "orders",
+		Configs:   []cluster.KafkaConfigUpdateItem{{Name: "retention.ms", Value: "3600000"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, topic.ReplicationFactor)
+
+	_, err = clusterClient.CreateTopic(&cluster.TopicCreateReq{TopicName: "orders"})
+	assert.ErrorIs(t, err, common.ErrConflict)
+
+	topics, err := clusterClient.ListTopics(nil)
+	require.NoError(t, err)
+	assert.Len(t, topics.Data, 1)
+
+	config, err := clusterClient.GetTopicConfig("orders", "retention.ms")
+	require.NoError(t, err)
+	assert.Equal(t, "3600000", config.Value)
+	assert.False(t, config.IsDefault)
+
+	require.NoError(t, clusterClient.UpdateTopicConfig("orders", "cleanup.policy", &cluster.KafkaConfigUpdateReq{Value: "compact"}))
+	require.NoError(t, clusterClient.ResetTopicConfig("orders", "retention.ms"))
+
+	configs, err := clusterClient.ListTopicConfigs("orders", nil)
+	require.NoError(t, err)
+	values := map[string]string{}
+	for _, config := range configs.Data {
+		values[config.Name] = config.Value
+	}
+	assert.Equal(t, "compact", values["cleanup.policy"])
+	assert.Equal(t, "604800000", values["retention.ms"])
+
+	_, err = clusterClient.GetTopic("missing")
+	assert.ErrorIs(t, err, common.ErrNotFound)
+
+	require.NoError(t, clusterClient.CreateAcl(&cluster.KafkaAclCreateReq{
+		ResourceType: cluster.AclResourceTypeTopic,
+		ResourceName: "orders",
+		PatternType:  cluster.AclPatternTypeLiteral,
+		Principal:    "User:sa-000001",
+		Host:         "*",
+		Operation:    cluster.AclOperationTypeRead,
+		Permission:   cluster.AclPermissionTypeAllow,
+	}))
+
+	err = clusterClient.CreateAcl(&cluster.KafkaAclCreateReq{ResourceType: cluster.AclResourceTypeTopic})
+	assert.ErrorIs(t, err, common.ErrValidation)
+
+	acls, err := clusterClient.SearchAcls(&cluster.KafkaAclSearchQry{Principal: "User:sa-000001"})
+	require.NoError(t, err)
+	require.Len(t, acls.Data, 1)
+	all, err := clusterClient.ListAllAcls(context.Background(), &cluster.KafkaAclSearchQry{Principal: "User:sa-000001"})
+	require.NoError(t, err)
+	assert.Equal(t, acls.Data, all)
+
+	require.NoError(t, clusterClient.DeleteAcl(&acls.Data[0]))
+	acls, err = clusterClient.SearchAcls(&cluster.KafkaAclSearchQry{})
+	require.NoError(t, err)
+	assert.Empty(t, acls.Data)
+
+	require.NoError(t, clusterClient.DeleteTopic("orders"))
+
+	missing, err := srv.ClusterClient("lkc-missing")
+	require.NoError(t, err)
+	_, err = missing.ListTopics(nil)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
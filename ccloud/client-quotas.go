@@ -1,10 +1,9 @@
 package ccloud
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"iter"
 	"strings"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
@@ -53,6 +52,16 @@ type ClientQuota struct {
 	Spec       ClientQuotaSpec `json:"spec,omitempty"`
 }
 
+func (c *ConfluentClient) clientQuotas() common.Resource[ClientQuotaDetail, ClientQuotaList] {
+	return common.Resource[ClientQuotaDetail, ClientQuotaList]{
+		Send: c.send,
+		Api:  "kafka-quotas",
+		Kind: "ClientQuota",
+		Path: "/kafka-quotas/v1/client-quotas",
+		Spec: true,
+	}
+}
+
 func (c *ClientQuota) String() string {
 	var result strings.Builder
 	result.WriteString(fmt.Sprintf("Client Quota ID: %s\n", c.ID))
@@ -172,6 +181,13 @@ type ListMetadata struct {
 	TotalSize int    `json:"total_size,omitempty"`
 }
 
+func (m *ListMetadata) GetPageNextToken() string {
+	if m.Next == "" {
+		return ""
+	}
+	return common.PageTokenFromUrl(m.Next)
+}
+
 type ClientQuotaListOptions struct {
 	common.PaginationOptions
 	Cluster     string `url:"spec.cluster,omitempty"`
@@ -196,126 +212,54 @@ type ClientQuotaUpdateReq struct {
 }
 
 func (c *ConfluentClient) ListClientQuotas(opt *ClientQuotaListOptions) (*ClientQuotaList, error) {
+	return c.ListClientQuotasWithContext(context.Background(), opt)
+}
+
+func (c *ConfluentClient) ListClientQuotasWithContext(ctx context.Context, opt *ClientQuotaListOptions) (*ClientQuotaList, error) {
 	if opt == nil {
 		return nil, fmt.Errorf("client quota list options cannot be nil")
 	}
 
-	urlPath := "/kafka-quotas/v1/client-quotas"
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		bodyBytes, _ := io.ReadAll(req.Body)
-		defer req.Body.Close()
-		return nil, fmt.Errorf("failed to list client quotas: status=%s, body=%s", req.Status, string(bodyBytes))
-	}
-
-	defer req.Body.Close()
-
-	bodyBytes, _ := io.ReadAll(req.Body)
+	return c.clientQuotas().List(ctx, opt)
+}
 
-	var clientQuotas ClientQuotaList
-	err = json.Unmarshal(bodyBytes, &clientQuotas)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding response: %w, body: %s", err, string(bodyBytes))
-	}
+func (c *ConfluentClient) AllClientQuotas(ctx context.Context, opt *ClientQuotaListOptions) iter.Seq2[ClientQuota, error] {
+	// Lists hold ClientQuota items, the resource returns ClientQuotaDetail.
+	return common.Resource[ClientQuota, ClientQuotaList](c.clientQuotas()).All(ctx, opt)
+}
 
-	return &clientQuotas, nil
+func (c *ConfluentClient) ListAllClientQuotas(ctx context.Context, opt *ClientQuotaListOptions) ([]ClientQuota, error) {
+	return common.Collect(c.AllClientQuotas(ctx, opt))
 }
 
 func (c *ConfluentClient) GetClientQuota(id string) (*ClientQuotaDetail, error) {
-	urlPath := fmt.Sprintf("/kafka-quotas/v1/client-quotas/%s", id)
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		bodyBytes, _ := io.ReadAll(req.Body)
-		defer req.Body.Close()
-		return nil, fmt.Errorf("failed to get client quota: status=%s, body=%s", req.Status, string(bodyBytes))
-	}
-
-	defer req.Body.Close()
-
-	bodyBytes, _ := io.ReadAll(req.Body)
-
-	var clientQuota ClientQuotaDetail
-	err = json.Unmarshal(bodyBytes, &clientQuota)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding response: %w, body: %s", err, string(bodyBytes))
-	}
+	return c.GetClientQuotaWithContext(context.Background(), id)
+}
 
-	return &clientQuota, nil
+func (c *ConfluentClient) GetClientQuotaWithContext(ctx context.Context, id string) (*ClientQuotaDetail, error) {
+	return c.clientQuotas().Get(ctx, id, nil)
 }
 
 func (c *ConfluentClient) CreateClientQuota(create *ClientQuotaCreateReq) (*ClientQuotaDetail, error) {
-	urlPath := "/kafka-quotas/v1/client-quotas"
-	req, err := c.doRequest(urlPath, http.MethodPost, specWrap{create}, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	defer req.Body.Close()
-
-	if http.StatusAccepted != req.StatusCode {
-		bodyBytes, _ := io.ReadAll(req.Body)
-		return nil, fmt.Errorf("failed to create client quota: status=%s, body=%s", req.Status, string(bodyBytes))
-	}
-
-	bodyBytes, _ := io.ReadAll(req.Body)
-
-	var clientQuota ClientQuotaDetail
-	err = json.Unmarshal(bodyBytes, &clientQuota)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding response: %w, body: %s", err, string(bodyBytes))
-	}
+	return c.CreateClientQuotaWithContext(context.Background(), create)
+}
 
-	return &clientQuota, nil
+func (c *ConfluentClient) CreateClientQuotaWithContext(ctx context.Context, create *ClientQuotaCreateReq) (*ClientQuotaDetail, error) {
+	return c.clientQuotas().Create(ctx, create, nil)
 }
 
 func (c *ConfluentClient) UpdateClientQuota(id string, update *ClientQuotaUpdateReq) (*ClientQuotaDetail, error) {
-	urlPath := fmt.Sprintf("/kafka-quotas/v1/client-quotas/%s", id)
-	req, err := c.doRequest(urlPath, http.MethodPatch, specWrap{update}, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		bodyBytes, _ := io.ReadAll(req.Body)
-		defer req.Body.Close()
-		return nil, fmt.Errorf("failed to update client quota: status=%s, body=%s", req.Status, string(bodyBytes))
-	}
-
-	defer req.Body.Close()
-
-	bodyBytes, _ := io.ReadAll(req.Body)
-
-	var clientQuota ClientQuotaDetail
-	err = json.Unmarshal(bodyBytes, &clientQuota)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding response: %w, body: %s", err, string(bodyBytes))
-	}
+	return c.UpdateClientQuotaWithContext(context.Background(), id, update)
+}
 
-	return &clientQuota, nil
+func (c *ConfluentClient) UpdateClientQuotaWithContext(ctx context.Context, id string, update *ClientQuotaUpdateReq) (*ClientQuotaDetail, error) {
+	return c.clientQuotas().Update(ctx, id, update, nil)
 }
 
 func (c *ConfluentClient) DeleteClientQuota(id string) error {
-	urlPath := fmt.Sprintf("/kafka-quotas/v1/client-quotas/%s", id)
-	req, err := c.doRequest(urlPath, http.MethodDelete, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusNoContent != req.StatusCode {
-		bodyBytes, _ := io.ReadAll(req.Body)
-		defer req.Body.Close()
-		return fmt.Errorf("failed to delete client quota: status=%s, body=%s", req.Status, string(bodyBytes))
-	}
-
-	defer req.Body.Close()
+	return c.DeleteClientQuotaWithContext(context.Background(), id)
+}
 
-	return nil
+func (c *ConfluentClient) DeleteClientQuotaWithContext(ctx context.Context, id string) error {
+	return c.clientQuotas().Delete(ctx, id, nil)
 }
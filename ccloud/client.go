@@ -1,29 +1,26 @@
 package ccloud
 
 import (
-	"bytes"
 	"context"
-	"io"
-
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/client"
-	"github.com/google/go-querystring/query"
-	"github.com/hashicorp/go-retryablehttp"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
 
-const ContentTypeJSON = "application/json"
+const ContentTypeJSON = client.ContentTypeJSON
 
 type ConfluentClient struct {
 	auth    client.ClientAuth
+	http    *client.HttpClient
 	BaseUrl string
 }
 
-func NewClient() *ConfluentClient {
+func NewClient(opts ...client.Option) *ConfluentClient {
 	return &ConfluentClient{
+		http:    client.NewHttpClient(opts...),
 		BaseUrl: "https://api.confluent.cloud",
 	}
 }
@@ -38,59 +35,26 @@ func (c *ConfluentClient) WithBaseUrl(baseUrl string) *ConfluentClient {
 	return c
 }
 
-type specWrap struct {
-	Spec interface{} `json:"spec"`
-}
-
-func (c *ConfluentClient) doRequest(urlPath, method string, body, params any) (*http.Response, error) {
-	client := retryablehttp.NewClient()
-	client.RetryMax = 10
-
-	client.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
-		ok, e := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
-		if !ok && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode >= 500 && resp.StatusCode != 501) {
-			return true, e
-		}
-		return ok, nil
+// send is the common.Sender of the resources of the client.
+func (c *ConfluentClient) send(ctx context.Context, req *common.Request) (*http.Response, error) {
+	base := req.Base
+	if base == "" {
+		base = c.BaseUrl
 	}
 
-	url, err := url.Parse(c.BaseUrl)
+	url, err := url.Parse(base)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse base url: %s", err)
 	}
 
-	url.Path = urlPath
-
-	var req *retryablehttp.Request
-
-	var bodyReader io.Reader
-
-	if body != nil {
-		bodyBuffer := new(bytes.Buffer)
-		err := json.NewEncoder(bodyBuffer).Encode(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to encode body: %s", err)
-		}
-		bodyReader = bodyBuffer
-	}
-
-	req, err = retryablehttp.NewRequest(method, url.String(), bodyReader)
-	if err != nil {
-		return nil, err
-	}
-
-	if err := c.auth.SetAuth(req.Request); err != nil {
-		return nil, fmt.Errorf("failed to set auth: %s", err)
-	}
-
-	req.Header.Add("Content-Type", ContentTypeJSON)
-
-	qry, err := query.Values(params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse query params: %s", err)
-	}
-
-	req.URL.RawQuery = qry.Encode()
+	url.Path = req.Path
 
-	return client.Do(req)
+	return c.http.Do(ctx, &client.Request{
+		Operation: req.Operation,
+		Method:    req.Method,
+		Url:       url.String(),
+		Body:      req.Body,
+		Params:    req.Params,
+		Auth:      c.auth,
+	})
 }
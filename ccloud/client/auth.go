@@ -0,0 +1,30 @@
+package client
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+func NewBasicAuth(username, password string) BasicAuth {
+	return BasicAuth{
+		Username: username,
+		Password: password,
+	}
+}
+
+func (a BasicAuth) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("username", a.Username),
+		slog.String("password", Redacted),
+	)
+}
+
+func (a BasicAuth) SetAuth(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
@@ -0,0 +1,260 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// Well known resources, usable as keys of the TTLs given to NewCache.
+const (
+	ResourceEnvironments      = ApiFamilyOrg + "/environments"
+	ResourceServiceAccounts   = ApiFamilyIam + "/service-accounts"
+	ResourceUsers             = ApiFamilyIam + "/users"
+	ResourceInvitations       = ApiFamilyIam + "/invitations"
+	ResourceApiKeys           = ApiFamilyIam + "/api-keys"
+	ResourceRoleBindings      = ApiFamilyIam + "/role-bindings"
+	ResourceIdentityProviders = ApiFamilyIam + "/identity-providers"
+	ResourceKafkaClusters     = ApiFamilyCmk + "/clusters"
+	ResourceClientQuotas      = ApiFamilyKafkaQuotas + "/client-quotas"
+	ResourceSchemaRegistry    = ApiFamilySchemaRegistry + "/clusters"
+)
+
+// cacheFlight is a GET shared by concurrent callers. It is cancelled once
+// every caller waiting on it has given up.
+type cacheFlight struct {
+	key     string
+	done    chan struct{}
+	entry   *cacheEntry
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+type cacheTTL struct {
+	prefix string
+	ttl    time.Duration
+}
+
+type cacheEntry struct {
+	path    string
+	res     *http.Response
+	body    []byte
+	expires time.Time
+}
+
+// Cache is a read-through cache of successful GET responses, with a TTL per
+// resource selected by the longest matching URL path prefix. The empty prefix
+// acts as a catch-all; paths without a TTL are not cached.
+//
+// Concurrent identical GETs share a single request, cached or not, which is
+// cancelled once every caller waiting on it is cancelled. Every mutation sent
+// through the cache invalidates the entries of its API family, e.g. a PATCH
+// of /iam/v2/users/u-1 drops everything under /iam/v2.
+//
+// A Cache can be shared by several clients and goroutines, as long as they
+// use the same credentials.
+type Cache struct {
+	ttls []cacheTTL
+
+	mu         sync.Mutex
+	entries    map[string]*cacheEntry
+	flights    map[string]*cacheFlight
+	generation uint64
+}
+
+func NewCache(ttls map[string]time.Duration) *Cache {
+	c := &Cache{entries: map[string]*cacheEntry{}, flights: map[string]*cacheFlight{}}
+
+	for prefix, ttl := range ttls {
+		c.ttls = append(c.ttls, cacheTTL{prefix: prefix, ttl: ttl})
+	}
+
+	sort.Slice(c.ttls, func(i, j int) bool {
+		return len(c.ttls[i].prefix) > len(c.ttls[j].prefix)
+	})
+
+	return c
+}
+
+// Invalidate drops the entries whose path starts with prefix.
+func (c *Cache) Invalidate(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for key, entry := range c.entries {
+		if strings.HasPrefix(entry.path, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Purge drops every entry.
+func (c *Cache) Purge() {
+	c.Invalidate("")
+}
+
+func (c *Cache) ttlFor(path string) time.Duration {
+	for _, ttl := range c.ttls {
+		if strings.HasPrefix(path, ttl.prefix) {
+			return ttl.ttl
+		}
+	}
+	return 0
+}
+
+func (c *Cache) lookup(key string) (*cacheEntry, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		entry = nil
+	}
+	return entry, c.generation
+}
+
+// store keeps entry unless a mutation happened since generation, in which
+// case the response may already be stale.
+func (c *Cache) store(key string, entry *cacheEntry, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation == generation {
+		c.entries[key] = entry
+	}
+}
+
+// response returns a copy of the cached response with its own body.
+func (e *cacheEntry) response() *http.Response {
+	res := *e.res
+	res.Header = e.res.Header.Clone()
+	res.Body = io.NopCloser(bytes.NewReader(e.body))
+	return &res
+}
+
+// apiFamily returns the first two segments of a path, e.g. "/iam/v2".
+func apiFamily(path string) string {
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(segments) < 2 {
+		return path
+	}
+	return "/" + segments[0] + "/" + segments[1]
+}
+
+func cacheKey(r *Request) (string, error) {
+	qry, err := query.Values(r.Params)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse query params: %s", err)
+	}
+	return r.Url + "?" + qry.Encode(), nil
+}
+
+func (c *Cache) handler(next Handler) Handler {
+	return func(ctx context.Context, r *Request) (*http.Response, error) {
+		if r.Method != http.MethodGet {
+			res, err := next(ctx, r)
+			c.Invalidate(apiFamily(r.Path()))
+			return res, err
+		}
+
+		key, err := cacheKey(r)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, generation := c.lookup(key)
+		if entry != nil {
+			return entry.response(), nil
+		}
+
+		// Requests started after a mutation must not join one started before.
+		f := c.join(ctx, fmt.Sprintf("%d:%s", generation, key), func(ctx context.Context) (*cacheEntry, error) {
+			res, err := next(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+
+			entry := &cacheEntry{path: r.Path(), res: res, body: body}
+			if ttl := c.ttlFor(entry.path); ttl > 0 && res.StatusCode == http.StatusOK {
+				entry.expires = time.Now().Add(ttl)
+				c.store(key, entry, generation)
+			}
+			return entry, nil
+		})
+
+		select {
+		case <-ctx.Done():
+			c.leave(f)
+			return nil, ctx.Err()
+		case <-f.done:
+			if f.err != nil {
+				return nil, f.err
+			}
+			return f.entry.response(), nil
+		}
+	}
+}
+
+// join returns the flight of key, starting it with fetch if there is none.
+// The flight keeps the values of the context that started it, but not its
+// cancellation, as other callers may be waiting on it.
+func (c *Cache) join(ctx context.Context, key string, fetch func(ctx context.Context) (*cacheEntry, error)) *cacheFlight {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, ok := c.flights[key]
+	if !ok {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &cacheFlight{key: key, done: make(chan struct{}), cancel: cancel}
+		c.flights[key] = f
+
+		go func() {
+			defer cancel()
+			f.entry, f.err = fetch(flightCtx)
+
+			c.mu.Lock()
+			if c.flights[f.key] == f {
+				delete(c.flights, f.key)
+			}
+			c.mu.Unlock()
+			close(f.done)
+		}()
+	}
+
+	f.waiters++
+	return f
+}
+
+// leave cancels f when the last caller waiting on it gives up, and lets
+// later callers start a new one.
+func (c *Cache) leave(f *cacheFlight) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f.waiters--
+	if f.waiters > 0 {
+		return
+	}
+
+	f.cancel()
+	if c.flights[f.key] == f {
+		delete(c.flights, f.key)
+	}
+}
@@ -0,0 +1,151 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, h *client.HttpClient, url string) string {
+	t.Helper()
+
+	res, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: url})
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestCache(t *testing.T) {
+	var hits atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		_, _ = io.WriteString(w, r.URL.Path+":"+strconv.Itoa(int(n)))
+	}))
+	defer ts.Close()
+
+	cache := client.NewCache(map[string]time.Duration{
+		client.ResourceEnvironments: time.Minute,
+		client.ResourceUsers:        time.Millisecond,
+	})
+	h := client.NewHttpClient(client.WithCache(cache))
+
+	assert.Equal(t, "/org/v2/environments/env-1:1", get(t, h, ts.URL+"/org/v2/environments/env-1"))
+	assert.Equal(t, "/org/v2/environments/env-1:1", get(t, h, ts.URL+"/org/v2/environments/env-1"))
+
+	// no ttl
+	assert.Equal(t, "/iam/v2/service-accounts/sa-1:2", get(t, h, ts.URL+"/iam/v2/service-accounts/sa-1"))
+	assert.Equal(t, "/iam/v2/service-accounts/sa-1:3", get(t, h, ts.URL+"/iam/v2/service-accounts/sa-1"))
+
+	// expired
+	assert.Equal(t, "/iam/v2/users/u-1:4", get(t, h, ts.URL+"/iam/v2/users/u-1"))
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, "/iam/v2/users/u-1:5", get(t, h, ts.URL+"/iam/v2/users/u-1"))
+
+	// a mutation of another family keeps the entry, one of the same family drops it
+	_, err := h.Do(context.Background(), &client.Request{Method: http.MethodDelete, Url: ts.URL + "/iam/v2/users/u-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "/org/v2/environments/env-1:1", get(t, h, ts.URL+"/org/v2/environments/env-1"))
+
+	_, err = h.Do(context.Background(), &client.Request{Method: http.MethodPatch, Url: ts.URL + "/org/v2/environments/env-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "/org/v2/environments/env-1:8", get(t, h, ts.URL+"/org/v2/environments/env-1"))
+
+	cache.Purge()
+	assert.Equal(t, "/org/v2/environments/env-1:9", get(t, h, ts.URL+"/org/v2/environments/env-1"))
+}
+
+func TestCacheCoalescesRequests(t *testing.T) {
+	const callers = 10
+
+	var hits atomic.Int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		_, _ = io.WriteString(w, "env-1")
+	}))
+	defer ts.Close()
+
+	h := client.NewHttpClient(client.WithCache(client.NewCache(nil)))
+
+	var wg sync.WaitGroup
+	bodies := make([]string, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bodies[i] = get(t, h, ts.URL+"/org/v2/environments/env-1")
+		}()
+	}
+
+	assert.Eventually(t, func() bool { return hits.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), hits.Load())
+	for _, body := range bodies {
+		assert.Equal(t, "env-1", body)
+	}
+
+	// without a ttl nothing is kept once the request is done
+	_ = get(t, h, ts.URL+"/org/v2/environments/env-1")
+	assert.Equal(t, int32(2), hits.Load())
+}
+
+func TestCacheCancelsAbandonedRequests(t *testing.T) {
+	started := make(chan struct{}, 2)
+	canceled := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-r.Context().Done()
+		close(canceled)
+	}))
+	defer ts.Close()
+
+	h := client.NewHttpClient(client.WithCache(client.NewCache(nil)))
+
+	// Both callers share the request, it only stops once both gave up.
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+
+	errs := make(chan error, 2)
+	for _, ctx := range []context.Context{first, second} {
+		go func() {
+			_, err := h.Do(ctx, &client.Request{Method: http.MethodGet, Url: ts.URL + "/org/v2/environments/env-1"})
+			errs <- err
+		}()
+	}
+	<-started
+	time.Sleep(50 * time.Millisecond)
+
+	cancelFirst()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-canceled:
+		t.Fatal("the request was canceled while a caller was still waiting")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancelSecond()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("the request kept running after every caller gave up")
+	}
+	assert.Len(t, started, 0, "the request was not retried")
+}
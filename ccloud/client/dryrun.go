@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/go-querystring/query"
+)
+
+// ErrDryRun is returned by every mutating call of a client in dry-run mode.
+// The call was added to the Plan and not sent.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// PlannedOperation is a mutating call captured in dry-run mode. Sensitive
+// values of the body are redacted.
+type PlannedOperation struct {
+	Operation string          `json:"operation"`
+	Method    string          `json:"method"`
+	Path      string          `json:"path"`
+	Query     string          `json:"query,omitempty"`
+	Body      json.RawMessage `json:"body,omitempty"`
+}
+
+func (o PlannedOperation) String() string {
+	line := o.Method + " " + o.Path
+	if o.Query != "" {
+		line += "?" + o.Query
+	}
+	if len(o.Body) > 0 {
+		line += " " + string(o.Body)
+	}
+	return line
+}
+
+// Plan collects the mutating calls of clients in dry-run mode, in the order
+// they were made. Reads are still sent. A Plan may be shared by several
+// clients, cluster clients built from a ConfluentClient share it by default.
+// It is safe for concurrent use.
+type Plan struct {
+	mu         sync.Mutex
+	operations []PlannedOperation
+}
+
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// Operations returns a copy of the planned operations.
+func (p *Plan) Operations() []PlannedOperation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return slices.Clone(p.operations)
+}
+
+func (p *Plan) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.operations)
+}
+
+// Reset discards the planned operations.
+func (p *Plan) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.operations = nil
+}
+
+// String renders the plan as one "METHOD path body" line per operation.
+func (p *Plan) String() string {
+	var sb strings.Builder
+	for _, op := range p.Operations() {
+		sb.WriteString(op.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Print writes the plan to w, see String.
+func (p *Plan) Print(w io.Writer) error {
+	_, err := io.WriteString(w, p.String())
+	return err
+}
+
+// MarshalJSON encodes the plan as an array of operations.
+func (p *Plan) MarshalJSON() ([]byte, error) {
+	operations := p.Operations()
+	if operations == nil {
+		operations = []PlannedOperation{}
+	}
+	return json.Marshal(operations)
+}
+
+func (p *Plan) add(r *Request) error {
+	op := PlannedOperation{
+		Operation: r.Operation,
+		Method:    r.Method,
+		Path:      r.Path(),
+	}
+
+	qry, err := query.Values(r.Params)
+	if err != nil {
+		return fmt.Errorf("failed to parse query params: %s", err)
+	}
+	op.Query = qry.Encode()
+
+	if r.Body != nil {
+		body, err := json.Marshal(r.Body)
+		if err != nil {
+			return fmt.Errorf("failed to encode body: %s", err)
+		}
+		op.Body = RedactJSON(body)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.operations = append(p.operations, op)
+	return nil
+}
+
+// handler records mutating requests instead of passing them to next.
+func (p *Plan) handler(next Handler) Handler {
+	return func(ctx context.Context, r *Request) (*http.Response, error) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return next(ctx, r)
+		}
+
+		if err := p.add(r); err != nil {
+			return nil, err
+		}
+		return nil, ErrDryRun
+	}
+}
@@ -0,0 +1,75 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	var sent []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	plan := client.NewPlan()
+	h := client.NewHttpClient(client.WithDryRun(plan))
+
+	res, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL + "/org/v2/environments"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	_, err = h.Do(context.Background(), &client.Request{
+		Operation: "connect.CreateConnector",
+		Method:    http.MethodPost,
+		Url:       ts.URL + "/connect/v1/environments/env-1/clusters/lkc-1/connectors",
+		Body:      map[string]any{"name": "sink", "config": map[string]string{"kafka.api.secret": "s3cr3t"}},
+	})
+	assert.ErrorIs(t, err, client.ErrDryRun)
+
+	_, err = h.Do(context.Background(), &client.Request{
+		Operation: "cmk.DeleteKafkaCluster",
+		Method:    http.MethodDelete,
+		Url:       ts.URL + "/cmk/v2/clusters/lkc-1",
+		Params: struct {
+			Environment string `url:"environment"`
+		}{"env-1"},
+	})
+	assert.ErrorIs(t, err, client.ErrDryRun)
+
+	assert.Equal(t, []string{"GET /org/v2/environments"}, sent)
+
+	ops := plan.Operations()
+	require.Len(t, ops, 2)
+	assert.Equal(t, client.PlannedOperation{
+		Operation: "cmk.DeleteKafkaCluster",
+		Method:    http.MethodDelete,
+		Path:      "/cmk/v2/clusters/lkc-1",
+		Query:     "environment=env-1",
+	}, ops[1])
+	assert.NotContains(t, string(ops[0].Body), "s3cr3t")
+
+	assert.Equal(t, strings.Join([]string{
+		`POST /connect/v1/environments/env-1/clusters/lkc-1/connectors {"config":{"kafka.api.secret":"[REDACTED]"},"name":"sink"}`,
+		"DELETE /cmk/v2/clusters/lkc-1?environment=env-1",
+	}, "\n")+"\n", plan.String())
+
+	data, err := json.Marshal(plan)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"operation":"connect.CreateConnector","method":"POST","path":"/connect/v1/environments/env-1/clusters/lkc-1/connectors","body":{"config":{"kafka.api.secret":"[REDACTED]"},"name":"sink"}},
+		{"operation":"cmk.DeleteKafkaCluster","method":"DELETE","path":"/cmk/v2/clusters/lkc-1","query":"environment=env-1"}
+	]`, string(data))
+
+	plan.Reset()
+	assert.Equal(t, 0, plan.Len())
+}
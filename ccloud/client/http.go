@@ -0,0 +1,257 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/go-querystring/query"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const ContentTypeJSON = "application/json"
+
+// Request describes a single API call before it is encoded.
+type Request struct {
+	// Operation names the API call, e.g. "cmk.CreateKafkaCluster".
+	Operation string
+	Method    string
+	Url       string
+	Body      any
+	Params    any
+	Auth      ClientAuth
+	// Header holds extra headers for this call only, set after the client
+	// wide ones.
+	Header http.Header
+}
+
+// Path returns the path of the request url.
+func (r *Request) Path() string {
+	u, err := url.Parse(r.Url)
+	if err != nil {
+		return r.Url
+	}
+	return u.Path
+}
+
+// HttpClient owns the long-lived transport of a client and executes requests
+// with retries. It is safe for concurrent use.
+type HttpClient struct {
+	options    *Options
+	httpClient *http.Client
+	handler    Handler
+	telemetry  *telemetry
+}
+
+func NewHttpClient(opts ...Option) *HttpClient {
+	options := NewOptions(opts...)
+
+	var httpClient *http.Client
+	if options.HttpClient != nil {
+		copied := *options.HttpClient
+		httpClient = &copied
+	} else {
+		httpClient = cleanhttp.DefaultPooledClient()
+	}
+
+	if options.Transport != nil {
+		httpClient.Transport = options.Transport
+	}
+
+	if options.RateLimiter != nil {
+		next := httpClient.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		httpClient.Transport = &rateLimitedTransport{limiter: options.RateLimiter, next: next}
+	}
+
+	if options.Timeout > 0 {
+		httpClient.Timeout = options.Timeout
+	}
+
+	if options.RetryPolicy == nil {
+		options.RetryPolicy = NewDefaultRetryPolicy()
+	}
+
+	h := &HttpClient{
+		options:    options,
+		httpClient: httpClient,
+		telemetry:  newTelemetry(options),
+	}
+
+	send := h.send
+	if options.Cache != nil {
+		send = options.Cache.handler(send)
+	}
+	if options.DryRun != nil {
+		send = options.DryRun.handler(send)
+	}
+	h.handler = Chain(options.Middlewares...)(send)
+
+	return h
+}
+
+func (h *HttpClient) Options() Options {
+	return *h.options
+}
+
+// Do runs the request through the middleware chain and sends it.
+func (h *HttpClient) Do(ctx context.Context, r *Request) (*http.Response, error) {
+	return h.handler(ctx, r)
+}
+
+func (h *HttpClient) send(ctx context.Context, r *Request) (*http.Response, error) {
+	var body []byte
+
+	if r.Body != nil {
+		bodyBuffer := new(bytes.Buffer)
+		err := json.NewEncoder(bodyBuffer).Encode(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode body: %s", err)
+		}
+		body = bodyBuffer.Bytes()
+	}
+
+	ctx, span := h.telemetry.start(ctx, r)
+
+	req, err := h.newRequest(ctx, r, body)
+	if err != nil {
+		h.telemetry.end(ctx, span, r, nil, err, 0, 0)
+		return nil, err
+	}
+
+	start := time.Now()
+	attempts := 0
+	h.logRequest(ctx, r, req.Request, body)
+
+	res, err := h.retryableClient(req.Request, &attempts).Do(req)
+
+	// An expired or revoked token is renewed and the request sent once more.
+	if auth, ok := r.Auth.(RefreshableAuth); ok && err == nil && res.StatusCode == http.StatusUnauthorized {
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		auth.Invalidate(req.Request)
+
+		req, err = h.newRequest(ctx, r, body)
+		if err != nil {
+			h.telemetry.end(ctx, span, r, nil, err, time.Since(start), attempts)
+			return nil, err
+		}
+		res, err = h.retryableClient(req.Request, &attempts).Do(req)
+	}
+
+	latency := time.Since(start)
+	h.logResponse(ctx, r, req.Request, res, err, latency, attempts)
+	h.telemetry.end(ctx, span, r, res, err, latency, attempts)
+
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (h *HttpClient) newRequest(ctx context.Context, r *Request, body []byte) (*retryablehttp.Request, error) {
+	var bodyReader any
+	if body != nil {
+		bodyReader = body
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, r.Method, r.Url, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	req.Header.Set("Accept", ContentTypeJSON)
+	if h.options.UserAgent != "" {
+		req.Header.Set("User-Agent", h.options.UserAgent)
+	}
+	for key, values := range h.options.Headers {
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	for key, values := range r.Header {
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	h.telemetry.inject(ctx, req.Header)
+
+	if r.Auth != nil {
+		if err := r.Auth.SetAuth(req.Request); err != nil {
+			return nil, fmt.Errorf("failed to set auth: %w", err)
+		}
+	}
+
+	qry, err := query.Values(r.Params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query params: %s", err)
+	}
+
+	if len(qry) > 0 {
+		req.URL.RawQuery = qry.Encode()
+	}
+
+	return req, nil
+}
+
+// retryableClient binds the retry policy to a single request. The underlying
+// http.Client, and so its connection pool, is shared by every request.
+func (h *HttpClient) retryableClient(req *http.Request, attempts *int) *retryablehttp.Client {
+	policy := h.options.RetryPolicy
+	stats := retryStatsFromContext(req.Context())
+
+	var lastErr error
+
+	return &retryablehttp.Client{
+		HTTPClient:   h.httpClient,
+		RetryMax:     h.options.RetryMax,
+		ErrorHandler: retryablehttp.PassthroughErrorHandler,
+		RequestLogHook: func(_ retryablehttp.Logger, _ *http.Request, _ int) {
+			*attempts++
+			if stats != nil {
+				stats.addAttempt()
+			}
+		},
+		CheckRetry: func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+			lastErr = err
+			return policy.Retry(ctx, req, resp, err)
+		},
+		Backoff: func(_, _ time.Duration, attempt int, resp *http.Response) time.Duration {
+			wait := policy.Backoff(attempt, resp)
+
+			event := RetryEvent{
+				Method:  req.Method,
+				Url:     req.URL.String(),
+				Attempt: attempt + 1,
+				Err:     lastErr,
+				Wait:    wait,
+			}
+			if resp != nil {
+				event.StatusCode = resp.StatusCode
+			}
+
+			if stats != nil {
+				stats.addRetry(event)
+			}
+			if h.options.RetryObserver != nil {
+				h.options.RetryObserver(req.Context(), event)
+			}
+			h.logRetry(req.Context(), event)
+			h.telemetry.retry(req.Context(), event)
+
+			return wait
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingTransport struct {
+	calls int32
+	next  http.RoundTripper
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	return t.next.RoundTrip(req)
+}
+
+func TestHttpClientHeaders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "my-app/1.0", r.Header.Get("User-Agent"))
+		assert.Equal(t, "team-a", r.Header.Get("X-Team"))
+		assert.Equal(t, client.ContentTypeJSON, r.Header.Get("Content-Type"))
+		assert.Equal(t, "page_size=5", r.URL.RawQuery)
+
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "key", user)
+		assert.Equal(t, "secret", pass)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	h := client.NewHttpClient(
+		client.WithUserAgent("my-app/1.0"),
+		client.WithHeader("X-Team", "team-a"),
+	)
+
+	res, err := h.Do(context.Background(), &client.Request{
+		Method: http.MethodGet,
+		Url:    ts.URL + "/org/v2/environments",
+		Params: struct {
+			PageSize int `url:"page_size"`
+		}{5},
+		Auth: client.NewBasicAuth("key", "secret"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestHttpClientReusesTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	transport := &countingTransport{next: http.DefaultTransport}
+	h := client.NewHttpClient(client.WithTransport(transport))
+
+	for i := 0; i < 3; i++ {
+		res, err := h.Do(context.Background(), &client.Request{Method: http.MethodDelete, Url: ts.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		res.Body.Close()
+	}
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&transport.calls))
+}
+
+func TestHttpClientTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	h := client.NewHttpClient(client.WithTimeout(20*time.Millisecond), client.WithRetryMax(0))
+
+	_, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL})
+	assert.Error(t, err)
+}
+
+func TestHttpClientKeepsCallerClient(t *testing.T) {
+	custom := &http.Client{Timeout: time.Minute}
+	h := client.NewHttpClient(client.WithHttpClient(custom), client.WithTimeout(time.Second))
+
+	assert.Equal(t, time.Minute, custom.Timeout)
+	assert.Equal(t, time.Second, h.Options().Timeout)
+}
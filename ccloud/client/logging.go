@@ -0,0 +1,108 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLoggedBodySize bounds the bodies written to debug logs.
+const maxLoggedBodySize = 4 << 10
+
+func (h *HttpClient) logRequest(ctx context.Context, r *Request, req *http.Request, body []byte) {
+	logger := h.options.Logger
+	if logger == nil || !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", operationName(r)),
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Any("header", RedactHeader(req.Header)),
+	}
+	if len(body) > 0 {
+		attrs = append(attrs, slog.String("body", loggedBody(body)))
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "ccloud request", attrs...)
+}
+
+func (h *HttpClient) logRetry(ctx context.Context, event RetryEvent) {
+	logger := h.options.Logger
+	if logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", event.Method),
+		slog.String("url", event.Url),
+		slog.Int("attempt", event.Attempt),
+		slog.Duration("wait", event.Wait),
+	}
+	if event.StatusCode != 0 {
+		attrs = append(attrs, slog.Int("status", event.StatusCode))
+	}
+	if event.Err != nil {
+		attrs = append(attrs, slog.String("error", event.Err.Error()))
+	}
+
+	logger.LogAttrs(ctx, slog.LevelWarn, "ccloud retry", attrs...)
+}
+
+func (h *HttpClient) logResponse(ctx context.Context, r *Request, req *http.Request, res *http.Response, err error, latency time.Duration, attempts int) {
+	logger := h.options.Logger
+	if logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", operationName(r)),
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Duration("latency", latency),
+		slog.Int("attempts", attempts),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		logger.LogAttrs(ctx, slog.LevelError, "ccloud request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.Int("status", res.StatusCode))
+
+	level := slog.LevelInfo
+	if res.StatusCode >= http.StatusInternalServerError {
+		level = slog.LevelWarn
+	}
+
+	if logger.Enabled(ctx, slog.LevelDebug) && res.Body != nil {
+		// The body is buffered so it can still be read by the caller.
+		data, readErr := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(data))
+		if readErr == nil && len(data) > 0 {
+			attrs = append(attrs, slog.String("body", loggedBody(data)))
+		}
+	}
+
+	logger.LogAttrs(ctx, level, "ccloud response", attrs...)
+}
+
+func loggedBody(body []byte) string {
+	redacted := RedactJSON(body)
+	if len(redacted) > maxLoggedBodySize {
+		// Cut on a rune boundary so the log line stays valid UTF-8.
+		cut := maxLoggedBodySize
+		for cut > 0 && !utf8.RuneStart(redacted[cut]) {
+			cut--
+		}
+		return string(redacted[:cut]) + "...(truncated)"
+	}
+	return string(bytes.TrimSpace(redacted))
+}
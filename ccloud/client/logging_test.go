@@ -0,0 +1,155 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerRedactsSecrets(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = io.WriteString(w, `{"id":"ABCKEY","spec":{"display_name":"ci","secret":"response-secret"}}`)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	h := client.NewHttpClient(
+		client.WithLogger(logger),
+		client.WithRetryPolicy(&client.DefaultRetryPolicy{WaitMin: time.Millisecond, WaitMax: time.Millisecond}),
+	)
+
+	res, err := h.Do(context.Background(), &client.Request{
+		Method: http.MethodPut,
+		Url:    ts.URL + "/connect/v1/environments/env-123/clusters/lkc-abc123/connectors/s3/config",
+		Body: map[string]any{
+			"connector.class":     "S3_SINK",
+			"kafka.api.key":       "KAFKAKEY",
+			"kafka.api.secret":    "connector-secret",
+			"connection.password": "db-password",
+		},
+		Auth: client.NewBasicAuth("key", "basic-secret"),
+	})
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), "response-secret", "caller still sees the real body")
+
+	logs := buf.String()
+	assert.Contains(t, logs, `"msg":"ccloud request"`)
+	assert.Contains(t, logs, `"msg":"ccloud retry"`)
+	assert.Contains(t, logs, `"msg":"ccloud response"`)
+	assert.Contains(t, logs, `"status":202`)
+	assert.Contains(t, logs, `"attempts":2`)
+	assert.Contains(t, logs, "KAFKAKEY")
+	assert.Contains(t, logs, client.Redacted)
+
+	for _, secret := range []string{"connector-secret", "db-password", "response-secret", "Basic "} {
+		assert.NotContains(t, logs, secret)
+	}
+}
+
+func TestLoggerTruncatesOnRuneBoundary(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// One ASCII byte shifts the two byte runes so the cut falls inside one.
+		_, _ = io.WriteString(w, "a"+strings.Repeat("é", 4<<10))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	_, err := client.NewHttpClient(client.WithLogger(logger)).Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL})
+	assert.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "é...(truncated)")
+	assert.NotContains(t, buf.String(), `\ufffd`)
+}
+
+func TestLoggerDisabledByDefault(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	defer ts.Close()
+
+	res, err := client.NewHttpClient().Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL})
+	assert.NoError(t, err)
+
+	body, _ := io.ReadAll(res.Body)
+	assert.JSONEq(t, `{"ok":true}`, string(body))
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "api key secret",
+			input:    `{"spec":{"display_name":"ci","secret":"s3cr3t"}}`,
+			expected: `{"spec":{"display_name":"ci","secret":"[REDACTED]"}}`,
+		},
+		{
+			name:     "sensitive config entry",
+			input:    `{"data":[{"name":"ssl.truststore.location","value":"/tmp/ts","is_sensitive":true},{"name":"retention.ms","value":"1000","is_sensitive":false}]}`,
+			expected: `{"data":[{"name":"ssl.truststore.location","value":"[REDACTED]","is_sensitive":true},{"name":"retention.ms","value":"1000","is_sensitive":false}]}`,
+		},
+		{
+			name:     "config named after a secret",
+			input:    `{"data":[{"name":"sasl.jaas.config","value":"org.apache.kafka..."}]}`,
+			expected: `{"data":[{"name":"sasl.jaas.config","value":"[REDACTED]"}]}`,
+		},
+		{
+			name:     "null secret is kept",
+			input:    `{"secret":null}`,
+			expected: `{"secret":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.JSONEq(t, tt.expected, string(client.RedactJSON([]byte(tt.input))))
+		})
+	}
+
+	assert.Equal(t, "not json", string(client.RedactJSON([]byte("not json"))))
+}
+
+func TestRedactKeepsLargeNumbers(t *testing.T) {
+	// 2^53 + 1 is the first integer a float64 cannot hold.
+	assert.Equal(t, `{"offset":9007199254740993}`, string(client.RedactJSON([]byte(`{"offset":9007199254740993}`))))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("partition", "value", client.RedactedLogValue(map[string]any{"offset": uint64(9007199254740993)}))
+	assert.Contains(t, buf.String(), `"offset":9007199254740993`)
+}
+
+func TestRedactHeader(t *testing.T) {
+	header := http.Header{"Authorization": {"Bearer token"}, "Accept": {"application/json"}}
+
+	redacted := client.RedactHeader(header)
+
+	assert.Equal(t, client.Redacted, redacted.Get("Authorization"))
+	assert.Equal(t, "application/json", redacted.Get("Accept"))
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Handler sends a Request and returns its response.
+type Handler func(ctx context.Context, req *Request) (*http.Response, error)
+
+// Middleware wraps a Handler. It may change the request before calling next,
+// inspect or replace the response, or short-circuit by returning without
+// calling next at all.
+type Middleware func(next Handler) Handler
+
+// Chain composes middlewares so that the first one sees the request first and
+// the response last.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next Handler) Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
@@ -0,0 +1,92 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func recordingMiddleware(name string, trace *[]string) client.Middleware {
+	return func(next client.Handler) client.Handler {
+		return func(ctx context.Context, req *client.Request) (*http.Response, error) {
+			*trace = append(*trace, name+":request")
+			res, err := next(ctx, req)
+			*trace = append(*trace, name+":response")
+			return res, err
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "abc", r.Header.Get("X-Correlation-Id"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var trace []string
+	injectHeader := func(next client.Handler) client.Handler {
+		return func(ctx context.Context, req *client.Request) (*http.Response, error) {
+			req.Header = http.Header{"X-Correlation-Id": {"abc"}}
+			return next(ctx, req)
+		}
+	}
+
+	h := client.NewHttpClient(
+		client.WithMiddleware(recordingMiddleware("outer", &trace)),
+		client.WithMiddleware(recordingMiddleware("inner", &trace), injectHeader),
+	)
+
+	res, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, []string{"outer:request", "inner:request", "inner:response", "outer:response"}, trace)
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s", r.URL.Path)
+	}))
+	defer ts.Close()
+
+	denyDeletes := func(next client.Handler) client.Handler {
+		return func(ctx context.Context, req *client.Request) (*http.Response, error) {
+			if req.Method == http.MethodDelete {
+				return &http.Response{
+					StatusCode: http.StatusForbidden,
+					Status:     "403 Forbidden",
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(`{"message":"deletes are disabled"}`)),
+				}, nil
+			}
+			return next(ctx, req)
+		}
+	}
+
+	var seenPath string
+	var seenBody any
+	capture := func(next client.Handler) client.Handler {
+		return func(ctx context.Context, req *client.Request) (*http.Response, error) {
+			seenPath, seenBody = req.Path(), req.Body
+			return next(ctx, req)
+		}
+	}
+
+	h := client.NewHttpClient(client.WithMiddleware(capture, denyDeletes))
+
+	res, err := h.Do(context.Background(), &client.Request{
+		Method: http.MethodDelete,
+		Url:    ts.URL + "/cmk/v2/clusters/lkc-abc123?environment=env-123",
+		Body:   map[string]string{"reason": "cleanup"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	assert.Equal(t, "/cmk/v2/clusters/lkc-abc123", seenPath)
+	assert.Equal(t, map[string]string{"reason": "cleanup"}, seenBody)
+}
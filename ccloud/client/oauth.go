@@ -0,0 +1,257 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultExpiryDelta is how long before its expiry a cached token is renewed.
+const DefaultExpiryDelta = 30 * time.Second
+
+// tokenFetchTimeout bounds a token request shared by several callers, as it
+// outlives the cancellation of the caller that started it.
+const tokenFetchTimeout = time.Minute
+
+// IdentityPoolHeader selects the identity pool a bearer token is mapped to.
+const IdentityPoolHeader = "Confluent-Identity-Pool-Id"
+
+type Token struct {
+	AccessToken string
+	TokenType   string
+	// Expiry is zero for tokens that never expire.
+	Expiry time.Time
+}
+
+func (t *Token) expired(delta time.Duration) bool {
+	if t == nil || t.AccessToken == "" {
+		return true
+	}
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return !time.Now().Add(delta).Before(t.Expiry)
+}
+
+// TokenSource fetches a fresh token every time it is called.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// ClientCredentials obtains tokens from an OAuth 2.0 token endpoint using the
+// client credentials grant.
+type ClientCredentials struct {
+	TokenUrl     string
+	ClientId     string
+	ClientSecret string
+	Scopes       []string
+	// EndpointParams are extra form values sent to the token endpoint, e.g.
+	// the audience required by some providers.
+	EndpointParams url.Values
+	// HttpClient is used to reach the token endpoint. When nil a default
+	// client is used.
+	HttpClient *http.Client
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (c *ClientCredentials) Token(ctx context.Context) (*Token, error) {
+	form := url.Values{}
+	for key, values := range c.EndpointParams {
+		form[key] = append([]string(nil), values...)
+	}
+	form.Set("grant_type", "client_credentials")
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", ContentTypeJSON)
+	req.SetBasicAuth(url.QueryEscape(c.ClientId), url.QueryEscape(c.ClientSecret))
+
+	httpClient := c.HttpClient
+	if httpClient == nil {
+		httpClient = cleanhttp.DefaultClient()
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	var tokenRes tokenResponse
+	if err := json.Unmarshal(body, &tokenRes); err != nil && res.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		if tokenRes.Error != "" {
+			return nil, fmt.Errorf("failed to fetch token: %s: %s %s", res.Status, tokenRes.Error, tokenRes.ErrorDescription)
+		}
+		return nil, fmt.Errorf("failed to fetch token: %s", res.Status)
+	}
+
+	if tokenRes.AccessToken == "" {
+		return nil, errors.New("failed to fetch token: response has no access_token")
+	}
+
+	token := &Token{
+		AccessToken: tokenRes.AccessToken,
+		TokenType:   tokenRes.TokenType,
+	}
+	if tokenRes.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenRes.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// RefreshableAuth is implemented by credentials that can be renewed when the
+// server rejects them. Requests answered with 401 Unauthorized are retried
+// once after Invalidate.
+type RefreshableAuth interface {
+	ClientAuth
+	// Invalidate discards the credential that was set on req.
+	Invalidate(req *http.Request)
+}
+
+// BearerAuth sets an OAuth bearer token on every request, caching it until
+// shortly before it expires. It is safe for concurrent use.
+type BearerAuth struct {
+	source         TokenSource
+	expiryDelta    time.Duration
+	identityPoolId string
+
+	fetch singleflight.Group
+
+	mu    sync.Mutex
+	token *Token
+}
+
+func NewBearerAuth(source TokenSource) *BearerAuth {
+	return &BearerAuth{
+		source:      source,
+		expiryDelta: DefaultExpiryDelta,
+	}
+}
+
+func NewClientCredentialsAuth(tokenUrl, clientId, clientSecret string, scopes ...string) *BearerAuth {
+	return NewBearerAuth(&ClientCredentials{
+		TokenUrl:     tokenUrl,
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+	})
+}
+
+// WithIdentityPoolId sends the identity pool the token maps to, required when
+// the token matches more than one pool.
+func (a *BearerAuth) WithIdentityPoolId(poolId string) *BearerAuth {
+	a.identityPoolId = poolId
+	return a
+}
+
+func (a *BearerAuth) WithExpiryDelta(delta time.Duration) *BearerAuth {
+	a.expiryDelta = delta
+	return a
+}
+
+// Token returns the cached token, fetching a new one when it is missing or
+// about to expire. Concurrent callers share a single fetch, and each one
+// stops waiting for it when its context is done.
+func (a *BearerAuth) Token(ctx context.Context) (*Token, error) {
+	if token := a.cached(); token != nil {
+		return token, nil
+	}
+
+	flight := a.fetch.DoChan("token", func() (any, error) {
+		// A fetch started just after another one completed would be wasted.
+		if token := a.cached(); token != nil {
+			return token, nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tokenFetchTimeout)
+		defer cancel()
+
+		token, err := a.source.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		a.mu.Lock()
+		a.token = token
+		a.mu.Unlock()
+
+		return token, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-flight:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*Token), nil
+	}
+}
+
+func (a *BearerAuth) cached() *Token {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token.expired(a.expiryDelta) {
+		return nil
+	}
+	return a.token
+}
+
+func (a *BearerAuth) SetAuth(req *http.Request) error {
+	token, err := a.Token(req.Context())
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	if a.identityPoolId != "" {
+		req.Header.Set(IdentityPoolHeader, a.identityPoolId)
+	}
+
+	return nil
+}
+
+func (a *BearerAuth) Invalidate(req *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Another request may already have replaced the rejected token.
+	if a.token != nil && req.Header.Get("Authorization") == "Bearer "+a.token.AccessToken {
+		a.token = nil
+	}
+}
@@ -0,0 +1,186 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func tokenServer(t *testing.T, issued *int32, expiresIn int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "kafka api", r.PostForm.Get("scope"))
+
+		id, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "my-client", id)
+		assert.Equal(t, "my-secret", secret)
+
+		n := atomic.AddInt32(issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+}
+
+func TestBearerAuthCachesToken(t *testing.T) {
+	var issued int32
+	idp := tokenServer(t, &issued, 3600)
+	defer idp.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+		assert.Equal(t, "pool-abc", r.Header.Get(client.IdentityPoolHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	auth := client.NewClientCredentialsAuth(idp.URL, "my-client", "my-secret", "kafka", "api").WithIdentityPoolId("pool-abc")
+	h := client.NewHttpClient()
+
+	for i := 0; i < 3; i++ {
+		res, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL, Auth: auth})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&issued))
+}
+
+func TestBearerAuthRefreshesBeforeExpiry(t *testing.T) {
+	var issued int32
+	idp := tokenServer(t, &issued, 10)
+	defer idp.Close()
+
+	auth := client.NewClientCredentialsAuth(idp.URL, "my-client", "my-secret", "kafka", "api").WithExpiryDelta(time.Minute)
+
+	first, err := auth.Token(context.Background())
+	assert.NoError(t, err)
+	second, err := auth.Token(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "token-1", first.AccessToken)
+	assert.Equal(t, "token-2", second.AccessToken)
+}
+
+func TestBearerAuthRetriesOnceOnUnauthorized(t *testing.T) {
+	var issued int32
+	idp := tokenServer(t, &issued, 3600)
+	defer idp.Close()
+
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		body := make([]byte, 64)
+		n, _ := r.Body.Read(body)
+		assert.JSONEq(t, `{"name":"orders"}`, string(body[:n]))
+
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	auth := client.NewClientCredentialsAuth(idp.URL, "my-client", "my-secret", "kafka", "api")
+	h := client.NewHttpClient()
+
+	res, err := h.Do(context.Background(), &client.Request{
+		Method: http.MethodPost,
+		Url:    ts.URL,
+		Body:   map[string]string{"name": "orders"},
+		Auth:   auth,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&issued))
+}
+
+func TestBearerAuthGivesUpAfterOneRefresh(t *testing.T) {
+	var issued int32
+	idp := tokenServer(t, &issued, 3600)
+	defer idp.Close()
+
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	auth := client.NewClientCredentialsAuth(idp.URL, "my-client", "my-secret", "kafka", "api")
+	h := client.NewHttpClient()
+
+	res, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL, Auth: auth})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestClientCredentialsError(t *testing.T) {
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_client","error_description":"bad secret"}`)
+	}))
+	defer idp.Close()
+
+	auth := client.NewClientCredentialsAuth(idp.URL, "my-client", "wrong")
+	h := client.NewHttpClient()
+
+	_, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: idp.URL, Auth: auth})
+	assert.ErrorContains(t, err, "invalid_client")
+}
+
+type blockingSource struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (s *blockingSource) Token(ctx context.Context) (*client.Token, error) {
+	s.calls.Add(1)
+	<-s.release
+	return &client.Token{AccessToken: "token"}, nil
+}
+
+func TestBearerAuthWaitHonorsContext(t *testing.T) {
+	source := &blockingSource{release: make(chan struct{})}
+	auth := client.NewBearerAuth(source)
+
+	results := make(chan error, 3)
+	for range 3 {
+		go func() {
+			_, err := auth.Token(context.Background())
+			results <- err
+		}()
+	}
+	assert.Eventually(t, func() bool { return source.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	// A caller with a deadline gives up while the shared fetch is stuck.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := auth.Token(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	close(source.release)
+	for range 3 {
+		assert.NoError(t, <-results)
+	}
+	assert.Equal(t, int32(1), source.calls.Load(), "concurrent callers share a single fetch")
+
+	token, err := auth.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token", token.AccessToken)
+	assert.Equal(t, int32(1), source.calls.Load())
+}
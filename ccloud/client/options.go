@@ -0,0 +1,184 @@
+package client
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	DefaultUserAgent = "ccloud-client-go"
+	DefaultRetryMax  = 10
+)
+
+// Options configures the HTTP layer shared by ConfluentClient and
+// ConfluentClusterClient.
+type Options struct {
+	// HttpClient is used to send requests. When nil a pooled client is created.
+	HttpClient *http.Client
+	// Transport replaces the RoundTripper of the underlying http.Client.
+	Transport http.RoundTripper
+	// RetryMax is the maximum number of retries for a single request.
+	RetryMax int
+	// Timeout bounds every attempt, including reading the response body.
+	Timeout   time.Duration
+	UserAgent string
+	// Headers are added to every outgoing request.
+	Headers     http.Header
+	RetryPolicy RetryPolicy
+	// RetryObserver is notified before every retry.
+	RetryObserver RetryObserver
+	// RateLimiter is consulted before every attempt, retries included.
+	RateLimiter *RateLimiter
+	// Logger receives request, retry and response logs with secrets redacted.
+	// Nothing is logged when nil.
+	Logger *slog.Logger
+	// TracerProvider and MeterProvider enable OpenTelemetry spans and metrics
+	// for every call. Propagator defaults to the global one.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	Propagator     propagation.TextMapPropagator
+	// Middlewares wrap every call, the first one being the outermost.
+	Middlewares []Middleware
+	// DryRun, when set, records mutating calls in the plan instead of sending
+	// them. They fail with ErrDryRun, after every middleware has seen them.
+	DryRun *Plan
+	// Cache, when set, serves GETs from a read-through cache.
+	Cache *Cache
+}
+
+type Option func(*Options)
+
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		RetryMax:    DefaultRetryMax,
+		UserAgent:   DefaultUserAgent,
+		Headers:     http.Header{},
+		RetryPolicy: NewDefaultRetryPolicy(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithOptions starts from a copy of base, e.g. the options of another client.
+// It should come first, as it replaces everything set before it.
+func WithOptions(base Options) Option {
+	return func(o *Options) {
+		*o = base
+		o.Headers = base.Headers.Clone()
+		if o.Headers == nil {
+			o.Headers = http.Header{}
+		}
+		o.Middlewares = slices.Clone(base.Middlewares)
+	}
+}
+
+func WithHttpClient(httpClient *http.Client) Option {
+	return func(o *Options) {
+		o.HttpClient = httpClient
+	}
+}
+
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *Options) {
+		o.Transport = transport
+	}
+}
+
+func WithRetryMax(retryMax int) Option {
+	return func(o *Options) {
+		o.RetryMax = retryMax
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.Timeout = timeout
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(o *Options) {
+		o.UserAgent = userAgent
+	}
+}
+
+func WithHeader(key, value string) Option {
+	return func(o *Options) {
+		o.Headers.Add(key, value)
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *Options) {
+		o.RetryPolicy = policy
+	}
+}
+
+func WithRetryObserver(observer RetryObserver) Option {
+	return func(o *Options) {
+		o.RetryObserver = observer
+	}
+}
+
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *Options) {
+		o.RateLimiter = limiter
+	}
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *Options) {
+		o.Logger = logger
+	}
+}
+
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *Options) {
+		o.TracerProvider = provider
+	}
+}
+
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(o *Options) {
+		o.MeterProvider = provider
+	}
+}
+
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(o *Options) {
+		o.Propagator = propagator
+	}
+}
+
+// WithMiddleware appends middlewares to the chain. They run in the order they
+// are added.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *Options) {
+		o.Middlewares = append(o.Middlewares, middlewares...)
+	}
+}
+
+// WithDryRun records POST, PUT, PATCH and DELETE calls in plan instead of
+// sending them. Reads are sent as usual.
+func WithDryRun(plan *Plan) Option {
+	return func(o *Options) {
+		o.DryRun = plan
+	}
+}
+
+// WithCache serves GETs through cache, see Cache.
+func WithCache(cache *Cache) Option {
+	return func(o *Options) {
+		o.Cache = cache
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"golang.org/x/time/rate"
+)
+
+// Well known API families, usable as keys of the limits given to NewRateLimiter.
+const (
+	ApiFamilyOrg            = "/org/v2"
+	ApiFamilyIam            = "/iam/v2"
+	ApiFamilyCmk            = "/cmk/v2"
+	ApiFamilyConnect        = "/connect/v1"
+	ApiFamilyKafkaRest      = "/kafka/v3"
+	ApiFamilyKafkaQuotas    = "/kafka-quotas/v1"
+	ApiFamilySchemaRegistry = "/srcm/v3"
+)
+
+// RateLimit is a token bucket budget: Rate requests per second with bursts of
+// up to Burst requests.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type familyLimiter struct {
+	prefix  string
+	limiter *rate.Limiter
+}
+
+// RateLimiter throttles requests per API family, selected by the longest
+// matching URL path prefix. The empty prefix acts as a catch-all. A single
+// RateLimiter can be shared by several clients and goroutines.
+type RateLimiter struct {
+	families []familyLimiter
+}
+
+func NewRateLimiter(limits map[string]RateLimit) *RateLimiter {
+	l := &RateLimiter{}
+
+	for prefix, limit := range limits {
+		burst := limit.Burst
+		if burst < 1 {
+			burst = 1
+		}
+
+		l.families = append(l.families, familyLimiter{
+			prefix:  prefix,
+			limiter: rate.NewLimiter(rate.Limit(limit.Rate), burst),
+		})
+	}
+
+	sort.Slice(l.families, func(i, j int) bool {
+		return len(l.families[i].prefix) > len(l.families[j].prefix)
+	})
+
+	return l
+}
+
+// Wait blocks until the family of the request has a token available or the
+// context is done.
+func (l *RateLimiter) Wait(ctx context.Context, req *http.Request) error {
+	if limiter := l.limiterFor(req.URL.Path); limiter != nil {
+		return limiter.Wait(ctx)
+	}
+	return nil
+}
+
+func (l *RateLimiter) limiterFor(path string) *rate.Limiter {
+	for _, family := range l.families {
+		if strings.HasPrefix(path, family.prefix) {
+			return family.limiter
+		}
+	}
+	return nil
+}
+
+type rateLimitedTransport struct {
+	limiter *RateLimiter
+	next    http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context(), req); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
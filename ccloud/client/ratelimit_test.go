@@ -0,0 +1,74 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterPerFamily(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	limiter := client.NewRateLimiter(map[string]client.RateLimit{
+		client.ApiFamilyIam: {Rate: 20, Burst: 1},
+	})
+
+	// Two clients sharing one limiter draw from the same budget.
+	first := client.NewHttpClient(client.WithRateLimiter(limiter))
+	second := client.NewHttpClient(client.WithRateLimiter(limiter))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		h := first
+		if i%2 == 1 {
+			h = second
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL + "/iam/v2/service-accounts"})
+			if assert.NoError(t, err) {
+				res.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+
+	start = time.Now()
+	for i := 0; i < 6; i++ {
+		res, err := first.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL + "/org/v2/environments"})
+		if assert.NoError(t, err) {
+			res.Body.Close()
+		}
+	}
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestRateLimiterLongestPrefix(t *testing.T) {
+	limiter := client.NewRateLimiter(map[string]client.RateLimit{
+		"":                         {Rate: 1000, Burst: 1000},
+		"/iam/v2":                  {Rate: 1000, Burst: 1000},
+		"/iam/v2/service-accounts": {Rate: 0.001, Burst: 1},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/iam/v2/service-accounts/sa-1", nil)
+	assert.NoError(t, limiter.Wait(context.Background(), req))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Error(t, limiter.Wait(ctx, req))
+
+	other := httptest.NewRequest(http.MethodGet, "/iam/v2/users", nil)
+	assert.NoError(t, limiter.Wait(context.Background(), other))
+}
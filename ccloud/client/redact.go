@@ -0,0 +1,136 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const Redacted = "[REDACTED]"
+
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// sensitiveKeyParts match JSON keys and config names holding credentials,
+// e.g. "secret", "kafka.api.secret", "connection.password" or "client_secret".
+var sensitiveKeyParts = []string{
+	"secret",
+	"password",
+	"passwd",
+	"private.key",
+	"private_key",
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"jaas.config",
+	"credentials.json",
+}
+
+// IsSensitiveKey reports whether a JSON key or config name holds a credential.
+func IsSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactHeader returns a copy of header with credentials masked.
+func RedactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := redacted[name]; ok {
+			redacted[name] = []string{Redacted}
+		}
+	}
+	return redacted
+}
+
+// RedactJSON returns a copy of a JSON document with every sensitive value
+// masked: keys matched by IsSensitiveKey, and the value of config entries that
+// are marked is_sensitive or named after a sensitive key. Documents that are
+// not JSON are returned unchanged.
+func RedactJSON(data []byte) []byte {
+	doc, err := decodeJSON(data)
+	if err != nil {
+		return data
+	}
+
+	redacted, err := json.Marshal(redactValue(doc))
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+// RedactedLogValue renders v as its redacted JSON form, for use in
+// slog.LogValuer implementations of types carrying secrets.
+func RedactedLogValue(v any) slog.Value {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return slog.StringValue(Redacted)
+	}
+
+	doc, err := decodeJSON(data)
+	if err != nil {
+		return slog.StringValue(Redacted)
+	}
+	return slog.AnyValue(redactValue(doc))
+}
+
+// decodeJSON keeps numbers as json.Number so ids above 2^53 survive the
+// round trip.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON document")
+	}
+	return doc, nil
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		sensitiveEntry := false
+		if flag, ok := v["is_sensitive"].(bool); ok && flag {
+			sensitiveEntry = true
+		}
+		if name, ok := v["name"].(string); ok && IsSensitiveKey(name) {
+			sensitiveEntry = true
+		}
+
+		for key, item := range v {
+			switch {
+			case IsSensitiveKey(key) && item != nil:
+				v[key] = Redacted
+			case sensitiveEntry && key == "value" && item != nil:
+				v[key] = Redacted
+			default:
+				v[key] = redactValue(item)
+			}
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	}
+	return value
+}
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// RetryPolicy decides whether a failed attempt is retried and how long to
+// wait before the next one.
+type RetryPolicy interface {
+	// Retry is called after every attempt. Returning an error stops retrying
+	// and is reported to the caller instead of the transport error.
+	Retry(ctx context.Context, req *http.Request, resp *http.Response, err error) (bool, error)
+	// Backoff returns the wait before retry number attempt (starting at 0).
+	Backoff(attempt int, resp *http.Response) time.Duration
+}
+
+// DefaultRetryPolicy retries rate limited requests on every method, server
+// errors and network failures only on idempotent methods, and never retries
+// authentication or authorization failures.
+type DefaultRetryPolicy struct {
+	// WaitMin is the first backoff, doubled on every attempt. Zero waits
+	// one second.
+	WaitMin time.Duration
+	WaitMax time.Duration
+	// MaxRetryAfter caps the wait requested by Retry-After and RateLimit-Reset
+	// headers, so that a bogus value cannot stall a call for hours. Zero caps
+	// it at WaitMax, or at two minutes when WaitMax is zero too.
+	MaxRetryAfter time.Duration
+	// RetryNonIdempotent allows POST and PATCH requests to be retried on
+	// server errors, at the risk of creating duplicates.
+	RetryNonIdempotent bool
+}
+
+const (
+	defaultRetryWaitMin  = time.Second
+	defaultMaxRetryAfter = 2 * time.Minute
+)
+
+func NewDefaultRetryPolicy() *DefaultRetryPolicy {
+	return &DefaultRetryPolicy{
+		WaitMin:       defaultRetryWaitMin,
+		WaitMax:       30 * time.Second,
+		MaxRetryAfter: defaultMaxRetryAfter,
+	}
+}
+
+func (p *DefaultRetryPolicy) Retry(ctx context.Context, req *http.Request, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	method := ""
+	if req != nil {
+		method = req.Method
+	}
+	replayable := p.RetryNonIdempotent || IsIdempotent(method)
+
+	if err != nil {
+		if ok, e := retryablehttp.ErrorPropagatedRetryPolicy(ctx, nil, err); !ok {
+			return false, e
+		}
+		// A failed dial means the request never left the client.
+		return replayable || isDialError(err), nil
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true, nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return false, nil
+	case resp.StatusCode == 0 || resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return replayable, nil
+	}
+
+	return false, nil
+}
+
+func (p *DefaultRetryPolicy) Backoff(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := RetryAfter(resp); ok {
+		limit := p.MaxRetryAfter
+		if limit <= 0 {
+			limit = p.WaitMax
+		}
+		if limit <= 0 {
+			limit = defaultMaxRetryAfter
+		}
+		return min(wait, limit)
+	}
+
+	waitMin := p.WaitMin
+	if waitMin <= 0 {
+		waitMin = defaultRetryWaitMin
+	}
+	wait := float64(waitMin) * math.Pow(2, float64(attempt))
+	if p.WaitMax > 0 && wait > float64(p.WaitMax) {
+		wait = float64(p.WaitMax)
+	}
+
+	// Jitter keeps concurrent callers from retrying in lockstep.
+	half := wait / 2
+	return time.Duration(half + rand.Float64()*half)
+}
+
+// IsIdempotent reports whether requests with the given method can be safely
+// sent more than once.
+func IsIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// RetryAfter extracts the server requested wait from a throttled response,
+// honoring Retry-After as well as the RateLimit-Reset headers.
+func RetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return max(time.Until(at), 0), true
+		}
+	}
+
+	for _, header := range []string{"RateLimit-Reset", "X-RateLimit-Reset"} {
+		value := resp.Header.Get(header)
+		if value == "" {
+			continue
+		}
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || seconds < 0 {
+			continue
+		}
+		// Some gateways send an epoch timestamp rather than a delta.
+		if seconds > 1_000_000_000 {
+			return max(time.Until(time.Unix(seconds, 0)), 0), true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	return 0, false
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// RetryEvent describes a retry that is about to happen.
+type RetryEvent struct {
+	Method     string
+	Url        string
+	Attempt    int
+	StatusCode int
+	Err        error
+	Wait       time.Duration
+}
+
+type RetryObserver func(ctx context.Context, event RetryEvent)
+
+// RetryStats collects the attempts made by the requests sharing a context
+// created with WithRetryStats.
+type RetryStats struct {
+	mu       sync.Mutex
+	attempts int
+	retries  []RetryEvent
+}
+
+func (s *RetryStats) Attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts
+}
+
+func (s *RetryStats) Retries() []RetryEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RetryEvent(nil), s.retries...)
+}
+
+func (s *RetryStats) addAttempt() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+}
+
+func (s *RetryStats) addRetry(event RetryEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retries = append(s.retries, event)
+}
+
+type retryStatsKey struct{}
+
+// WithRetryStats returns a context that records every attempt and retry of
+// the calls made with it.
+func WithRetryStats(ctx context.Context) (context.Context, *RetryStats) {
+	stats := &RetryStats{}
+	return context.WithValue(ctx, retryStatsKey{}, stats), stats
+}
+
+func retryStatsFromContext(ctx context.Context) *RetryStats {
+	stats, _ := ctx.Value(retryStatsKey{}).(*RetryStats)
+	return stats
+}
@@ -0,0 +1,181 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func fastPolicy() *client.DefaultRetryPolicy {
+	return &client.DefaultRetryPolicy{WaitMin: time.Millisecond, WaitMax: 5 * time.Millisecond}
+}
+
+func statusServer(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		status := statuses[len(statuses)-1]
+		if int(n) <= len(statuses) {
+			status = statuses[n-1]
+		}
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &calls
+}
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		statuses []int
+		status   int
+		attempts int32
+	}{
+		{"rate limited post is retried", http.MethodPost, []int{429, 201}, 201, 2},
+		{"server error on post is not retried", http.MethodPost, []int{500, 201}, 500, 1},
+		{"server error on get is retried", http.MethodGet, []int{502, 503, 200}, 200, 3},
+		{"server error on delete is retried", http.MethodDelete, []int{500, 204}, 204, 2},
+		{"unauthorized is not retried", http.MethodGet, []int{401, 200}, 401, 1},
+		{"not implemented is not retried", http.MethodGet, []int{501, 200}, 501, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, calls := statusServer(t, tt.statuses...)
+			h := client.NewHttpClient(client.WithRetryPolicy(fastPolicy()))
+
+			ctx, stats := client.WithRetryStats(context.Background())
+			res, err := h.Do(ctx, &client.Request{Method: tt.method, Url: ts.URL})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+			assert.Equal(t, tt.attempts, atomic.LoadInt32(calls))
+			assert.Equal(t, int(tt.attempts), stats.Attempts())
+			assert.Len(t, stats.Retries(), int(tt.attempts)-1)
+		})
+	}
+}
+
+func TestRetryPolicyNonIdempotentOptIn(t *testing.T) {
+	ts, calls := statusServer(t, 500, 201)
+	policy := fastPolicy()
+	policy.RetryNonIdempotent = true
+	h := client.NewHttpClient(client.WithRetryPolicy(policy))
+
+	res, err := h.Do(context.Background(), &client.Request{Method: http.MethodPost, Url: ts.URL})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestRetryObserver(t *testing.T) {
+	ts, _ := statusServer(t, 429, 429, 200)
+
+	var events []client.RetryEvent
+	h := client.NewHttpClient(
+		client.WithRetryPolicy(fastPolicy()),
+		client.WithRetryObserver(func(_ context.Context, event client.RetryEvent) {
+			events = append(events, event)
+		}),
+	)
+
+	res, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, 1, events[0].Attempt)
+		assert.Equal(t, 2, events[1].Attempt)
+		assert.Equal(t, http.StatusTooManyRequests, events[0].StatusCode)
+		assert.Equal(t, time.Duration(0), events[0].Wait)
+	}
+}
+
+func TestRetryGivesUpWithLastResponse(t *testing.T) {
+	ts, calls := statusServer(t, 503)
+	h := client.NewHttpClient(client.WithRetryPolicy(fastPolicy()), client.WithRetryMax(2))
+
+	res, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := func(key, value string) http.Header {
+		h := http.Header{}
+		h.Set(key, value)
+		return h
+	}
+
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		wait   time.Duration
+		ok     bool
+	}{
+		{"seconds", 429, header("Retry-After", "7"), 7 * time.Second, true},
+		{"http date in the past", 429, header("Retry-After", "Fri, 31 Dec 1999 23:59:59 GMT"), 0, true},
+		{"rate limit reset", 429, header("RateLimit-Reset", "3"), 3 * time.Second, true},
+		{"x rate limit reset", 503, header("X-RateLimit-Reset", "2"), 2 * time.Second, true},
+		{"not throttled", 500, header("Retry-After", "7"), 0, false},
+		{"no header", 429, http.Header{}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := client.RetryAfter(&http.Response{StatusCode: tt.status, Header: tt.header})
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.wait, wait)
+		})
+	}
+}
+
+func TestBackoffCapsRetryAfter(t *testing.T) {
+	throttled := func(key, value string) *http.Response {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		resp.Header.Set(key, value)
+		return resp
+	}
+	farFuture := strconv.FormatInt(time.Now().Add(1000*time.Hour).Unix(), 10)
+
+	policy := client.NewDefaultRetryPolicy()
+	assert.Equal(t, 7*time.Second, policy.Backoff(0, throttled("Retry-After", "7")))
+	assert.Equal(t, 2*time.Minute, policy.Backoff(0, throttled("Retry-After", "86400")))
+	assert.Equal(t, 2*time.Minute, policy.Backoff(0, throttled("RateLimit-Reset", farFuture)))
+
+	policy = &client.DefaultRetryPolicy{WaitMin: time.Millisecond, WaitMax: 5 * time.Second}
+	assert.Equal(t, 5*time.Second, policy.Backoff(0, throttled("Retry-After", "86400")), "falls back to WaitMax")
+
+	policy = &client.DefaultRetryPolicy{}
+	assert.Equal(t, 2*time.Minute, policy.Backoff(0, throttled("Retry-After", "86400")), "capped without WaitMax")
+}
+
+func TestBackoffZeroValueWaits(t *testing.T) {
+	policy := &client.DefaultRetryPolicy{}
+	for attempt := 0; attempt < 3; attempt++ {
+		assert.GreaterOrEqual(t, policy.Backoff(attempt, nil), 500*time.Millisecond<<attempt)
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	assert.True(t, client.IsIdempotent(http.MethodGet))
+	assert.True(t, client.IsIdempotent(http.MethodPut))
+	assert.True(t, client.IsIdempotent(http.MethodDelete))
+	assert.False(t, client.IsIdempotent(http.MethodPost))
+	assert.False(t, client.IsIdempotent(http.MethodPatch))
+}
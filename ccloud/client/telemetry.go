@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/electric-saw/ccloud-client-go"
+
+const (
+	AttrOperation  = attribute.Key("ccloud.operation")
+	AttrRetryCount = attribute.Key("ccloud.retry_count")
+	AttrStatusCode = attribute.Key("http.response.status_code")
+	AttrMethod     = attribute.Key("http.request.method")
+	AttrUrl        = attribute.Key("url.full")
+)
+
+// resourceIdAttrs maps Confluent Cloud id prefixes to span attributes.
+var resourceIdAttrs = map[string]attribute.Key{
+	"env":  "ccloud.environment.id",
+	"lkc":  "ccloud.kafka_cluster.id",
+	"lsrc": "ccloud.schema_registry.id",
+	"lcc":  "ccloud.connector.id",
+	"sa":   "ccloud.service_account.id",
+	"u":    "ccloud.user.id",
+	"rb":   "ccloud.role_binding.id",
+	"pool": "ccloud.identity_pool.id",
+	"op":   "ccloud.identity_provider.id",
+	"cq":   "ccloud.client_quota.id",
+}
+
+var resourceIdPattern = regexp.MustCompile(`^(env|lkc|lsrc|lcc|sa|u|rb|pool|op|cq)-[a-zA-Z0-9]+$`)
+
+// idCollections are the path segments followed by a resource id. Others,
+// such as topics or connectors, are followed by user chosen names that may
+// look like ids, e.g. a topic named "sa-events".
+var idCollections = map[string]bool{
+	"environments":       true,
+	"clusters":           true,
+	"service-accounts":   true,
+	"users":              true,
+	"role-bindings":      true,
+	"identity-providers": true,
+	"identity-pools":     true,
+	"client-quotas":      true,
+}
+
+// idParams are the query parameters holding a resource id.
+var idParams = []string{"environment", "spec.environment", "spec.cluster", "spec.owner", "spec.resource"}
+
+type telemetry struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   metric.Float64Histogram
+	requests   metric.Int64Counter
+	errors     metric.Int64Counter
+}
+
+func newTelemetry(o *Options) *telemetry {
+	if o.TracerProvider == nil && o.MeterProvider == nil {
+		return nil
+	}
+
+	t := &telemetry{propagator: o.Propagator}
+	if t.propagator == nil {
+		t.propagator = otel.GetTextMapPropagator()
+	}
+
+	if o.TracerProvider != nil {
+		t.tracer = o.TracerProvider.Tracer(instrumentationName)
+	}
+
+	if o.MeterProvider != nil {
+		if err := t.initMetrics(o.MeterProvider.Meter(instrumentationName)); err != nil {
+			// Tracing keeps working when the instruments cannot be created.
+			otel.Handle(err)
+			t.duration, t.requests, t.errors = nil, nil, nil
+		}
+	}
+
+	return t
+}
+
+func (t *telemetry) initMetrics(meter metric.Meter) error {
+	var err error
+	t.duration, err = meter.Float64Histogram("ccloud.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of Confluent API calls, retries included."))
+	if err != nil {
+		return err
+	}
+	t.requests, err = meter.Int64Counter("ccloud.client.requests",
+		metric.WithDescription("Number of Confluent API calls."))
+	if err != nil {
+		return err
+	}
+	t.errors, err = meter.Int64Counter("ccloud.client.errors",
+		metric.WithDescription("Number of Confluent API calls that failed or returned an error status."))
+	return err
+}
+
+func operationName(r *Request) string {
+	if r.Operation != "" {
+		return r.Operation
+	}
+	return r.Method + " " + r.Path()
+}
+
+// ResourceIdAttributes extracts the Confluent Cloud resource ids found in a
+// request url, from the path segments naming a resource and the query
+// parameters filtering on one.
+func ResourceIdAttributes(rawUrl string) []attribute.KeyValue {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil
+	}
+
+	var candidates []string
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		if idCollections[segments[i-1]] {
+			candidates = append(candidates, segments[i])
+		}
+	}
+	query := u.Query()
+	for _, param := range idParams {
+		candidates = append(candidates, query[param]...)
+	}
+
+	var attrs []attribute.KeyValue
+	seen := map[attribute.Key]bool{}
+
+	for _, candidate := range candidates {
+		match := resourceIdPattern.FindStringSubmatch(candidate)
+		if match == nil {
+			continue
+		}
+		key := resourceIdAttrs[match[1]]
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		attrs = append(attrs, key.String(match[0]))
+	}
+
+	return attrs
+}
+
+// spanUrl drops the query of a url, which holds page tokens and filters.
+func spanUrl(rawUrl string) string {
+	base, _, _ := strings.Cut(rawUrl, "?")
+	return base
+}
+
+// start opens the span of a call. The returned context carries it so that
+// every attempt, retries included, propagates the same trace.
+func (t *telemetry) start(ctx context.Context, r *Request) (context.Context, trace.Span) {
+	if t == nil || t.tracer == nil {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	attrs := append([]attribute.KeyValue{
+		AttrOperation.String(operationName(r)),
+		AttrMethod.String(r.Method),
+		AttrUrl.String(spanUrl(r.Url)),
+	}, ResourceIdAttributes(r.Url)...)
+
+	return t.tracer.Start(ctx, operationName(r),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
+
+func (t *telemetry) inject(ctx context.Context, header http.Header) {
+	if t == nil || t.tracer == nil {
+		return
+	}
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+func (t *telemetry) retry(ctx context.Context, event RetryEvent) {
+	if t == nil || t.tracer == nil {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.Int("attempt", event.Attempt),
+		attribute.String("wait", event.Wait.String()),
+	}
+	if event.StatusCode != 0 {
+		attrs = append(attrs, AttrStatusCode.Int(event.StatusCode))
+	}
+	if event.Err != nil {
+		attrs = append(attrs, attribute.String("error", event.Err.Error()))
+	}
+
+	trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(attrs...))
+}
+
+func (t *telemetry) end(ctx context.Context, span trace.Span, r *Request, res *http.Response, err error, latency time.Duration, attempts int) {
+	if t == nil {
+		return
+	}
+
+	failed := err != nil || res.StatusCode >= http.StatusBadRequest
+
+	metricAttrs := []attribute.KeyValue{
+		AttrOperation.String(operationName(r)),
+		AttrMethod.String(r.Method),
+	}
+	if res != nil {
+		metricAttrs = append(metricAttrs, AttrStatusCode.Int(res.StatusCode))
+	}
+
+	if t.duration != nil {
+		set := metric.WithAttributes(metricAttrs...)
+		t.duration.Record(ctx, latency.Seconds(), set)
+		t.requests.Add(ctx, 1, set)
+		if failed {
+			t.errors.Add(ctx, 1, set)
+		}
+	}
+
+	if t.tracer == nil {
+		return
+	}
+
+	span.SetAttributes(AttrRetryCount.Int(max(attempts-1, 0)))
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case failed:
+		span.SetAttributes(AttrStatusCode.Int(res.StatusCode))
+		span.SetStatus(codes.Error, strconv.Itoa(res.StatusCode)+" "+http.StatusText(res.StatusCode))
+	default:
+		span.SetAttributes(AttrStatusCode.Int(res.StatusCode))
+	}
+	span.End()
+}
@@ -0,0 +1,165 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTelemetrySpansAndMetrics(t *testing.T) {
+	var calls int32
+	var mu sync.Mutex
+	var traceparents []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		traceparents = append(traceparents, r.Header.Get("Traceparent"))
+		mu.Unlock()
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	h := client.NewHttpClient(
+		client.WithTracerProvider(tracerProvider),
+		client.WithMeterProvider(meterProvider),
+		client.WithPropagator(propagation.TraceContext{}),
+		client.WithRetryPolicy(&client.DefaultRetryPolicy{WaitMin: time.Millisecond, WaitMax: time.Millisecond}),
+	)
+
+	res, err := h.Do(context.Background(), &client.Request{
+		Operation: "cmk.CreateKafkaCluster",
+		Method:    http.MethodPost,
+		Url:       ts.URL + "/cmk/v2/clusters?environment=env-123",
+		Body:      map[string]string{"display_name": "orders"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, res.StatusCode)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "cmk.CreateKafkaCluster", span.Name())
+	assert.Equal(t, int64(1), spanAttr(span, client.AttrRetryCount).AsInt64())
+	assert.Equal(t, int64(http.StatusAccepted), spanAttr(span, client.AttrStatusCode).AsInt64())
+	assert.Equal(t, "env-123", spanAttr(span, "ccloud.environment.id").AsString())
+	assert.Equal(t, ts.URL+"/cmk/v2/clusters", spanAttr(span, client.AttrUrl).AsString(), "the query is left out")
+	assert.Len(t, span.Events(), 1)
+	assert.Equal(t, "retry", span.Events()[0].Name)
+
+	// Every attempt carries the same trace.
+	assert.Len(t, traceparents, 2)
+	assert.Equal(t, traceparents[0], traceparents[1])
+	assert.Contains(t, traceparents[0], span.SpanContext().TraceID().String())
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	counts := map[string]int64{}
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, point := range sum.DataPoints {
+					counts[m.Name] += point.Value
+				}
+			}
+			if hist, ok := m.Data.(metricdata.Histogram[float64]); ok {
+				for _, point := range hist.DataPoints {
+					counts[m.Name] += int64(point.Count)
+				}
+			}
+		}
+	}
+	assert.Equal(t, int64(1), counts["ccloud.client.requests"])
+	assert.Equal(t, int64(1), counts["ccloud.client.request.duration"])
+	assert.Equal(t, int64(0), counts["ccloud.client.errors"])
+}
+
+func TestTelemetryErrorStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	h := client.NewHttpClient(client.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+
+	_, err := h.Do(context.Background(), &client.Request{Method: http.MethodGet, Url: ts.URL + "/kafka/v3/clusters/lkc-abc123/topics/orders"})
+	assert.NoError(t, err)
+
+	span := recorder.Ended()[0]
+	assert.Equal(t, "GET /kafka/v3/clusters/lkc-abc123/topics/orders", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "lkc-abc123", spanAttr(span, "ccloud.kafka_cluster.id").AsString())
+}
+
+func TestResourceIdAttributes(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want []attribute.KeyValue
+	}{
+		{
+			name: "path",
+			url:  "https://api.confluent.cloud/connect/v1/environments/env-1/clusters/lkc-2/connectors/orders",
+			want: []attribute.KeyValue{
+				attribute.String("ccloud.environment.id", "env-1"),
+				attribute.String("ccloud.kafka_cluster.id", "lkc-2"),
+			},
+		},
+		{
+			name: "query",
+			url:  "https://api.confluent.cloud/iam/v2/api-keys?spec.owner=sa-1&page_token=u-abc",
+			want: []attribute.KeyValue{attribute.String("ccloud.service_account.id", "sa-1")},
+		},
+		{
+			name: "names that look like ids",
+			url:  "https://pkc.confluent.cloud/kafka/v3/clusters/lkc-2/topics/my-sa-events",
+			want: []attribute.KeyValue{attribute.String("ccloud.kafka_cluster.id", "lkc-2")},
+		},
+		{
+			name: "whole name that looks like an id",
+			url:  "https://api.confluent.cloud/connect/v1/environments/env-1/clusters/lkc-2/connectors/u-sink",
+			want: []attribute.KeyValue{
+				attribute.String("ccloud.environment.id", "env-1"),
+				attribute.String("ccloud.kafka_cluster.id", "lkc-2"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, client.ResourceIdAttributes(tt.url))
+		})
+	}
+}
@@ -1,20 +1,15 @@
 package ccloud
 
-import "net/http"
+import "github.com/electric-saw/ccloud-client-go/ccloud/client"
 
-type BasicAuth struct {
-	Username string
-	Password string
-}
+type BasicAuth = client.BasicAuth
+
+type BearerAuth = client.BearerAuth
 
 func NewBasicAuth(username, password string) BasicAuth {
-	return BasicAuth{
-		Username: username,
-		Password: password,
-	}
+	return client.NewBasicAuth(username, password)
 }
 
-func (a BasicAuth) SetAuth(req *http.Request) error {
-	req.SetBasicAuth(a.Username, a.Password)
-	return nil
+func NewClientCredentialsAuth(tokenUrl, clientId, clientSecret string, scopes ...string) *BearerAuth {
+	return client.NewClientCredentialsAuth(tokenUrl, clientId, clientSecret, scopes...)
 }
@@ -1,11 +1,15 @@
 package ccloud_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListClientQuotas(t *testing.T) {
@@ -13,7 +17,7 @@ func TestListClientQuotas(t *testing.T) {
 		t.Skip("Skipping integration test in short mode")
 	}
 
-	c := makeClient()
+	c := makeClient(t)
 
 	environments, err := c.ListEnvironments(&common.PaginationOptions{
 		PageSize: 1})
@@ -65,7 +69,7 @@ func TestCreateUpdateDeleteClientQuota(t *testing.T) {
 		t.Skip("Skipping integration test in short mode")
 	}
 
-	c := makeClient()
+	c := makeClient(t)
 
 	environments, err := c.ListEnvironments(&common.PaginationOptions{PageSize: 1})
 	assert.NoError(t, err)
@@ -145,3 +149,73 @@ func TestCreateUpdateDeleteClientQuota(t *testing.T) {
 	_, err = c.GetClientQuota(createdQuota.ID)
 	assert.Error(t, err)
 }
+
+func TestClientQuotaRequests(t *testing.T) {
+	quota := ccloud.ClientQuotaDetail{ID: "cq-1"}
+	deleted := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /kafka-quotas/v1/client-quotas":
+			var req struct {
+				Spec ccloud.ClientQuotaCreateReq `json:"spec"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "lkc-1", req.Spec.Cluster.ID)
+			assert.Equal(t, []ccloud.ClientQuotaPrincipal{{ID: "sa-1"}}, req.Spec.Principals)
+
+			quota.Spec = ccloud.ClientQuotaSpec(req.Spec)
+			w.WriteHeader(http.StatusCreated)
+		case "PATCH /kafka-quotas/v1/client-quotas/cq-1":
+			var req struct {
+				Spec ccloud.ClientQuotaUpdateReq `json:"spec"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+			quota.Spec.DisplayName = req.Spec.DisplayName
+			quota.Spec.Throughput = req.Spec.Throughput
+		case "GET /kafka-quotas/v1/client-quotas/cq-1":
+			if deleted {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+		case "DELETE /kafka-quotas/v1/client-quotas/cq-1":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(quota)
+	}))
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	created, err := client.CreateClientQuota(&ccloud.ClientQuotaCreateReq{
+		DisplayName: "Test Quota",
+		Throughput:  &ccloud.ClientQuotaThroughput{IngressByteRate: "1048576", EgressByteRate: "1048576"},
+		Cluster:     &ccloud.ClientQuotaCluster{ID: "lkc-1"},
+		Principals:  []ccloud.ClientQuotaPrincipal{{ID: "sa-1"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Test Quota", created.Spec.DisplayName)
+
+	updated, err := client.UpdateClientQuota(created.ID, &ccloud.ClientQuotaUpdateReq{
+		DisplayName: "Updated Test Quota",
+		Throughput:  &ccloud.ClientQuotaThroughput{IngressByteRate: "2097152", EgressByteRate: "2097152"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2097152", updated.Spec.Throughput.IngressByteRate)
+
+	fetched, err := client.GetClientQuota(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated Test Quota", fetched.Spec.DisplayName)
+
+	require.NoError(t, client.DeleteClientQuota(created.ID))
+	_, err = client.GetClientQuota(created.ID)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
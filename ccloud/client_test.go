@@ -0,0 +1,76 @@
+package ccloud_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestCanceledDuringRetries(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result, err := client.ListEnvironmentsWithContext(ctx, nil)
+
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, result)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Less(t, atomic.LoadInt32(&calls), int32(3))
+}
+
+func TestRequestAlreadyCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s", r.URL.Path)
+	}))
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.DeleteEnvironmentWithContext(ctx, "env-123")
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClientMiddleware(t *testing.T) {
+	var paths []string
+	capture := func(next client.Handler) client.Handler {
+		return func(ctx context.Context, req *client.Request) (*http.Response, error) {
+			paths = append(paths, req.Operation+" "+req.Method+" "+req.Path())
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`{"id":"env-123","display_name":"prod"}`)),
+			}, nil
+		}
+	}
+
+	c := ccloud.NewClient(client.WithMiddleware(capture)).WithAuth(noopAuth{}).WithBaseUrl("http://127.0.0.1:0")
+
+	environment, err := c.GetEnvironment("env-123")
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", environment.DisplayName)
+	assert.Equal(t, []string{"org.GetEnvironment GET /org/v2/environments/env-123"}, paths)
+}
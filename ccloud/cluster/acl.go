@@ -1,8 +1,8 @@
 package cluster
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"iter"
 	"net/http"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
@@ -78,25 +78,32 @@ type KafkaAclSearchQry struct {
 	PatternType  AclPatternType    `url:"pattern_type,omitempty"`
 }
 
-func (c *ConfluentClusterClient) SearchAcls(qry *KafkaAclSearchQry) (*KafkaAclList, error) {
-	res, err := c.doRequest(c.clusterInfo.Acls.Related, "", "GET", nil, qry)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClusterClient) acls() common.Resource[KafkaAcl, KafkaAclList] {
+	return common.Resource[KafkaAcl, KafkaAclList]{
+		Send: c.sendTo(linkAcls),
+		Api:  "kafka",
+		Kind: "Acl",
 	}
+}
 
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to search acls: %s", res.Status)
-	}
+func (c *ConfluentClusterClient) SearchAcls(qry *KafkaAclSearchQry) (*KafkaAclList, error) {
+	return c.SearchAclsWithContext(context.Background(), qry)
+}
 
-	defer res.Body.Close()
+func (c *ConfluentClusterClient) SearchAclsWithContext(ctx context.Context, qry *KafkaAclSearchQry) (*KafkaAclList, error) {
+	return common.Do[KafkaAclList](ctx, c.sendTo(linkAcls), &common.Request{
+		Operation: "kafka.SearchAcls",
+		Method:    http.MethodGet,
+		Params:    qry,
+	})
+}
 
-	var list KafkaAclList
-	err = json.NewDecoder(res.Body).Decode(&list)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClusterClient) AllAcls(ctx context.Context, qry *KafkaAclSearchQry) iter.Seq2[KafkaAcl, error] {
+	return c.acls().All(ctx, qry)
+}
 
-	return &list, nil
+func (c *ConfluentClusterClient) ListAllAcls(ctx context.Context, qry *KafkaAclSearchQry) ([]KafkaAcl, error) {
+	return common.Collect(c.AllAcls(ctx, qry))
 }
 
 type KafkaAclCreateReq struct {
@@ -114,60 +121,45 @@ type KafkaAclBatchCreateReq struct {
 }
 
 func (c *ConfluentClusterClient) CreateAcl(acl *KafkaAclCreateReq) error {
-	res, err := c.doRequest(c.clusterInfo.Acls.Related, "", "POST", acl, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusCreated != res.StatusCode {
-		errorRes, err := common.NewErrorResponse(res.Body)
-		if err != nil {
-			return err
-		}
-
-		return fmt.Errorf("failed to create acl (%s):  %s", res.Status, errorRes.Error())
-	}
+	return c.CreateAclWithContext(context.Background(), acl)
+}
 
-	return nil
+func (c *ConfluentClusterClient) CreateAclWithContext(ctx context.Context, acl *KafkaAclCreateReq) error {
+	return common.Exec(ctx, c.sendTo(linkAcls), &common.Request{
+		Operation: "kafka.CreateAcl",
+		Method:    http.MethodPost,
+		Body:      acl,
+	})
 }
 
 func (c *ConfluentClusterClient) BatchCreateAcls(batch *KafkaAclBatchCreateReq) error {
-	// Build URL with :batch suffix
-	urlPath := c.clusterInfo.Acls.Related + ":batch"
-
-	res, err := c.doRequest(urlPath, "", "POST", batch, nil)
-	if err != nil {
-		return err
-	}
-
-	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusNoContent {
-		errorRes, err := common.NewErrorResponse(res.Body)
-		if err != nil {
-			return err
-		}
-
-		return fmt.Errorf("failed to batch create acls (%s): %s", res.Status, errorRes.Error())
-	}
+	return c.BatchCreateAclsWithContext(context.Background(), batch)
+}
 
-	return nil
+func (c *ConfluentClusterClient) BatchCreateAclsWithContext(ctx context.Context, batch *KafkaAclBatchCreateReq) error {
+	return common.Exec(ctx, c.sendTo(linkAcls), &common.Request{
+		Operation: "kafka.BatchCreateAcls",
+		Method:    http.MethodPost,
+		Path:      ":batch",
+		Body:      batch,
+	})
 }
 
 func (c *ConfluentClusterClient) DeleteAcl(acl *KafkaAcl) error {
-	var url string
-	if acl.Metadata.Self != nil && *acl.Metadata.Self != "" {
-		url = *acl.Metadata.Self
-	} else {
-		url = c.clusterInfo.Acls.Related
-	}
+	return c.DeleteAclWithContext(context.Background(), acl)
+}
 
-	res, err := c.doRequest(url, "", "DELETE", nil, acl)
-	if err != nil {
-		return err
+func (c *ConfluentClusterClient) DeleteAclWithContext(ctx context.Context, acl *KafkaAcl) error {
+	req := &common.Request{
+		Operation: "kafka.DeleteAcl",
+		Method:    http.MethodDelete,
+		Params:    acl,
 	}
 
-	if http.StatusOK != res.StatusCode && http.StatusNoContent != res.StatusCode {
-		return fmt.Errorf("failed to delete acl: %s", res.Status)
+	// An ACL read from the API links to itself, no discovery is needed.
+	if acl.Metadata.Self != nil && *acl.Metadata.Self != "" {
+		req.Base = *acl.Metadata.Self
+		return common.Exec(ctx, c.send, req)
 	}
-
-	return nil
+	return common.Exec(ctx, c.sendTo(linkAcls), req)
 }
@@ -0,0 +1,28 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+)
+
+// BulkCreateTopics creates topics concurrently, see common.Bulk.
+func (c *ConfluentClusterClient) BulkCreateTopics(ctx context.Context, reqs []*TopicCreateReq, opts ...common.BulkOption) *common.BulkReport[*TopicCreateReq, *Topic] {
+	return common.Bulk(ctx, reqs, c.CreateTopicWithContext, opts...)
+}
+
+// BulkDeleteTopics deletes topics by name concurrently, see common.Bulk.
+func (c *ConfluentClusterClient) BulkDeleteTopics(ctx context.Context, topicNames []string, opts ...common.BulkOption) *common.BulkReport[string, struct{}] {
+	return common.BulkDo(ctx, topicNames, c.DeleteTopicWithContext, opts...)
+}
+
+// BulkCreateAcls creates ACLs one request each, reporting the outcome of
+// every ACL, unlike BatchCreateAcls. See common.Bulk.
+func (c *ConfluentClusterClient) BulkCreateAcls(ctx context.Context, acls []*KafkaAclCreateReq, opts ...common.BulkOption) *common.BulkReport[*KafkaAclCreateReq, struct{}] {
+	return common.BulkDo(ctx, acls, c.CreateAclWithContext, opts...)
+}
+
+// BulkDeleteAcls deletes ACLs concurrently, see common.Bulk.
+func (c *ConfluentClusterClient) BulkDeleteAcls(ctx context.Context, acls []*KafkaAcl, opts ...common.BulkOption) *common.BulkReport[*KafkaAcl, struct{}] {
+	return common.BulkDo(ctx, acls, c.DeleteAclWithContext, opts...)
+}
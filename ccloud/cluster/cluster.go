@@ -1,7 +1,7 @@
 package cluster
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 
@@ -24,24 +24,11 @@ type KafkaCluster struct {
 	PartitionReassignments *Resource `json:"partition_reassignments"`
 }
 
-func (c *ConfluentClusterClient) getCluster() (*KafkaCluster, error) {
+func (c *ConfluentClusterClient) getCluster(ctx context.Context) (*KafkaCluster, error) {
 	urlPath := fmt.Sprintf("/kafka/v3/clusters/%s", c.ClusterId)
-	req, err := c.doRequest(c.BaseUrl, urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get cluster: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var cluster KafkaCluster
-	err = json.NewDecoder(req.Body).Decode(&cluster)
-	if err != nil {
-		return nil, err
-	}
-
-	return &cluster, nil
+	return common.Do[KafkaCluster](ctx, c.send, &common.Request{
+		Operation: "kafka.GetCluster",
+		Method:    http.MethodGet,
+		Path:      urlPath,
+	})
 }
@@ -2,90 +2,210 @@ package cluster
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/google/go-querystring/query"
-	"github.com/hashicorp/go-retryablehttp"
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"golang.org/x/sync/singleflight"
 )
 
+// discoveryTimeout bounds a discovery shared by several calls, as it
+// outlives the cancellation of the call that started it.
+const discoveryTimeout = time.Minute
+
 type ConfluentClusterClient struct {
-	user        string
-	password    string
-	BaseUrl     string
-	ClusterId   string
+	auth      client.ClientAuth
+	http      *client.HttpClient
+	BaseUrl   string
+	ClusterId string
+
+	discovery   singleflight.Group
+	mu          sync.Mutex
 	clusterInfo *KafkaCluster
 }
 
-func NewClusterClient(user, password, clusterId, clusterUrl string) (*ConfluentClusterClient, error) {
-	client := &ConfluentClusterClient{
-		user:      user,
-		password:  password,
+// NewClusterClient creates a client for the Kafka REST API of a cluster. No
+// request is made until the first call, which discovers the cluster links.
+func NewClusterClient(auth client.ClientAuth, clusterId, clusterUrl string, opts ...client.Option) (*ConfluentClusterClient, error) {
+	if clusterId == "" {
+		return nil, fmt.Errorf("cluster id is required")
+	}
+
+	parsed, err := url.Parse(clusterUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cluster url: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("cluster url %q must be absolute", clusterUrl)
+	}
+
+	return &ConfluentClusterClient{
+		auth:      auth,
+		http:      client.NewHttpClient(opts...),
 		BaseUrl:   clusterUrl,
 		ClusterId: clusterId,
-	}
+	}, nil
+}
 
-	clusterInfo, err := client.getCluster()
+// NewClusterClientWithContext creates a cluster client and discovers the
+// cluster right away, failing fast when it is unreachable.
+func NewClusterClientWithContext(ctx context.Context, auth client.ClientAuth, clusterId, clusterUrl string, opts ...client.Option) (*ConfluentClusterClient, error) {
+	clusterClient, err := NewClusterClient(auth, clusterId, clusterUrl, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	client.clusterInfo = clusterInfo
+	if err := clusterClient.Discover(ctx); err != nil {
+		return nil, err
+	}
 
-	return client, nil
+	return clusterClient, nil
 }
 
-func (c *ConfluentClusterClient) doRequest(base string, urlPath, method string, body, params interface{}) (*http.Response, error) {
-	client := retryablehttp.NewClient()
-	client.RetryMax = 10
+// Discover fetches the cluster and its related links. It is called on first
+// use; a failed discovery is attempted again by the next call.
+func (c *ConfluentClusterClient) Discover(ctx context.Context) error {
+	_, err := c.cluster(ctx)
+	return err
+}
 
-	client.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
-		ok, e := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
-		if !ok && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode >= 500 && resp.StatusCode != 501) {
-			return true, e
-		}
-		return ok, nil
+// cluster returns the discovered cluster. Concurrent calls share a single
+// discovery, and each one stops waiting for it when its context is done.
+func (c *ConfluentClusterClient) cluster(ctx context.Context) (*KafkaCluster, error) {
+	if clusterInfo := c.discovered(); clusterInfo != nil {
+		return clusterInfo, nil
 	}
 
-	if base == "" {
-		base = c.BaseUrl
+	flight := c.discovery.DoChan("cluster", func() (any, error) {
+		if clusterInfo := c.discovered(); clusterInfo != nil {
+			return clusterInfo, nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), discoveryTimeout)
+		defer cancel()
+
+		clusterInfo, err := c.getCluster(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.clusterInfo = clusterInfo
+		c.mu.Unlock()
+
+		return clusterInfo, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-flight:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*KafkaCluster), nil
 	}
+}
 
-	url, err := url.Parse(base)
+func (c *ConfluentClusterClient) discovered() *KafkaCluster {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.clusterInfo
+}
+
+type relatedLink int
+
+const (
+	linkAcls relatedLink = iota
+	linkBrokerConfigs
+	linkConsumerGroups
+	linkTopics
+)
+
+func (c *ConfluentClusterClient) related(ctx context.Context, link relatedLink) (string, error) {
+	clusterInfo, err := c.cluster(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse base url: %s", err)
+		return "", err
 	}
 
-	url.Path = path.Join(url.Path, urlPath)
+	var resource *Resource
+	switch link {
+	case linkAcls:
+		resource = clusterInfo.Acls
+	case linkBrokerConfigs:
+		resource = clusterInfo.BrokerConfigs
+	case linkConsumerGroups:
+		resource = clusterInfo.ConsumerGroups
+	case linkTopics:
+		resource = clusterInfo.Topics
+	}
 
-	var req *retryablehttp.Request
-	if body != nil {
-		bodyReader, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal body: %s", err)
-		}
-		req, err = retryablehttp.NewRequest(method, url.String(), bodyReader)
+	if resource == nil || resource.Related == "" {
+		return "", fmt.Errorf("cluster %s has no related link for %s", c.ClusterId, link)
+	}
+
+	return resource.Related, nil
+}
+
+func (l relatedLink) String() string {
+	switch l {
+	case linkAcls:
+		return "acls"
+	case linkBrokerConfigs:
+		return "broker_configs"
+	case linkConsumerGroups:
+		return "consumer_groups"
+	case linkTopics:
+		return "topics"
+	}
+	return "unknown"
+}
+
+// sendTo sends requests relative to the related link of the cluster,
+// discovered on the first request, see related. A path starting with ":",
+// such as ":batch", names a custom method of the link itself.
+func (c *ConfluentClusterClient) sendTo(link relatedLink) common.Sender {
+	return func(ctx context.Context, req *common.Request) (*http.Response, error) {
+		base, err := c.related(ctx, link)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		req, err = retryablehttp.NewRequest(method, url.String(), nil)
-		if err != nil {
-			return nil, err
+
+		related := *req
+		related.Base = base
+		if strings.HasPrefix(req.Path, ":") {
+			related.Base, related.Path = base+req.Path, ""
 		}
+		return c.send(ctx, &related)
 	}
+}
 
-	req.Request.SetBasicAuth(c.user, c.password)
+func (c *ConfluentClusterClient) send(ctx context.Context, req *common.Request) (*http.Response, error) {
+	base := req.Base
+	if base == "" {
+		base = c.BaseUrl
+	}
 
-	qry, err := query.Values(params)
+	url, err := url.Parse(base)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse query params: %s", err)
+		return nil, fmt.Errorf("failed to parse base url: %s", err)
 	}
 
-	req.URL.RawQuery = qry.Encode()
+	url.Path = path.Join(url.Path, req.Path)
 
-	return client.Do(req)
+	return c.http.Do(ctx, &client.Request{
+		Operation: req.Operation,
+		Method:    req.Method,
+		Url:       url.String(),
+		Body:      req.Body,
+		Params:    req.Params,
+		Auth:      c.auth,
+	})
 }
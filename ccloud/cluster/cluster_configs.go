@@ -1,91 +1,79 @@
 package cluster
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"iter"
 	"net/http"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
 
-func (c *ConfluentClusterClient) ListKafkaConfigs(opt *common.PaginationOptions) (*KafkaConfigList, error) {
-	req, err := c.doRequest(c.clusterInfo.BrokerConfigs.Related, "", http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClusterClient) kafkaConfigs() common.Resource[KafkaConfig, KafkaConfigList] {
+	return common.Resource[KafkaConfig, KafkaConfigList]{
+		Send: c.sendTo(linkBrokerConfigs),
+		Api:  "kafka",
+		Kind: "KafkaConfig",
 	}
+}
 
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list kafka configs: %s", req.Status)
-	}
+func (c *ConfluentClusterClient) ListKafkaConfigs(opt *common.PaginationOptions) (*KafkaConfigList, error) {
+	return c.ListKafkaConfigsWithContext(context.Background(), opt)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClusterClient) ListKafkaConfigsWithContext(ctx context.Context, opt *common.PaginationOptions) (*KafkaConfigList, error) {
+	return c.kafkaConfigs().List(ctx, opt)
+}
 
-	var configList KafkaConfigList
-	err = json.NewDecoder(req.Body).Decode(&configList)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClusterClient) AllKafkaConfigs(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[KafkaConfig, error] {
+	return c.kafkaConfigs().All(ctx, opt)
+}
 
-	return &configList, nil
+func (c *ConfluentClusterClient) ListAllKafkaConfigs(ctx context.Context, opt *common.PaginationOptions) ([]KafkaConfig, error) {
+	return common.Collect(c.AllKafkaConfigs(ctx, opt))
 }
 
 func (c *ConfluentClusterClient) GetKafkaConfig(configName string) (*KafkaConfig, error) {
-	req, err := c.doRequest(c.clusterInfo.BrokerConfigs.Related, configName, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get kafka config: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var config KafkaConfig
-	err = json.NewDecoder(req.Body).Decode(&config)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetKafkaConfigWithContext(context.Background(), configName)
+}
 
-	return &config, nil
+func (c *ConfluentClusterClient) GetKafkaConfigWithContext(ctx context.Context, configName string) (*KafkaConfig, error) {
+	return c.kafkaConfigs().Get(ctx, configName, nil)
 }
 
 func (c *ConfluentClusterClient) UpdateKafkaConfig(configName string, req *KafkaConfigUpdateReq) error {
-	res, err := c.doRequest(c.clusterInfo.BrokerConfigs.Related, configName, http.MethodPut, req, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusNoContent != res.StatusCode {
-		return fmt.Errorf("failed to update kafka config: %s", res.Status)
-	}
+	return c.UpdateKafkaConfigWithContext(context.Background(), configName, req)
+}
 
-	return nil
+func (c *ConfluentClusterClient) UpdateKafkaConfigWithContext(ctx context.Context, configName string, req *KafkaConfigUpdateReq) error {
+	return common.Exec(ctx, c.sendTo(linkBrokerConfigs), &common.Request{
+		Operation: "kafka.UpdateKafkaConfig",
+		Method:    http.MethodPut,
+		Path:      configName,
+		Body:      req,
+	})
 }
 
 func (c *ConfluentClusterClient) UpdateKafkaConfigBatch(req *KafkaConfigUpdateBatch) error {
-	urlPath := fmt.Sprintf("%s:alter", c.clusterInfo.BrokerConfigs.Related)
-	res, err := c.doRequest(c.clusterInfo.BrokerConfigs.Related, urlPath, http.MethodPost, req, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusNoContent != res.StatusCode {
-		return fmt.Errorf("failed to update kafka config: %s", res.Status)
-	}
+	return c.UpdateKafkaConfigBatchWithContext(context.Background(), req)
+}
 
-	return nil
+func (c *ConfluentClusterClient) UpdateKafkaConfigBatchWithContext(ctx context.Context, req *KafkaConfigUpdateBatch) error {
+	return common.Exec(ctx, c.sendTo(linkBrokerConfigs), &common.Request{
+		Operation: "kafka.UpdateKafkaConfigBatch",
+		Method:    http.MethodPost,
+		Path:      ":alter",
+		Body:      req,
+	})
 }
 
 func (c *ConfluentClusterClient) ResetKafkaConfig(configName string) error {
-	res, err := c.doRequest(c.clusterInfo.BrokerConfigs.Related, configName, http.MethodDelete, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusNoContent != res.StatusCode {
-		return fmt.Errorf("failed to delete kafka config: %s", res.Status)
-	}
+	return c.ResetKafkaConfigWithContext(context.Background(), configName)
+}
 
-	return nil
+func (c *ConfluentClusterClient) ResetKafkaConfigWithContext(ctx context.Context, configName string) error {
+	return common.Exec(ctx, c.sendTo(linkBrokerConfigs), &common.Request{
+		Operation: "kafka.ResetKafkaConfig",
+		Method:    http.MethodDelete,
+		Path:      configName,
+	})
 }
@@ -1,7 +1,7 @@
 package cluster
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 
@@ -48,50 +48,36 @@ type MirrorTopicReq struct {
 }
 
 func (c *ConfluentClusterClient) GetClusterLinking() (*ClusterLinking, error) {
-	urlPath := fmt.Sprintf("/kafka/v3/clusters/%s/links", c.ClusterId)
-	req, err := c.doRequest(c.BaseUrl, urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get cluster linkings: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var clusterLinking ClusterLinking
-	err = json.NewDecoder(req.Body).Decode(&clusterLinking)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetClusterLinkingWithContext(context.Background())
+}
 
-	return &clusterLinking, nil
+func (c *ConfluentClusterClient) GetClusterLinkingWithContext(ctx context.Context) (*ClusterLinking, error) {
+	urlPath := fmt.Sprintf("/kafka/v3/clusters/%s/links", c.ClusterId)
+	return common.Do[ClusterLinking](ctx, c.send, &common.Request{
+		Operation: "kafka.GetClusterLinking",
+		Method:    http.MethodGet,
+		Path:      urlPath,
+	})
 }
 
 func (c *ConfluentClusterClient) GetClusterLinkingConfig(linkName string) (*ClusterLinkingConfig, error) {
-	urlPath := fmt.Sprintf("/kafka/v3/clusters/%s/links/%s/configs", c.ClusterId, linkName)
-	req, err := c.doRequest(c.BaseUrl, urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get cluster linking config: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var clusterLinkingConfig ClusterLinkingConfig
-	err = json.NewDecoder(req.Body).Decode(&clusterLinkingConfig)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetClusterLinkingConfigWithContext(context.Background(), linkName)
+}
 
-	return &clusterLinkingConfig, nil
+func (c *ConfluentClusterClient) GetClusterLinkingConfigWithContext(ctx context.Context, linkName string) (*ClusterLinkingConfig, error) {
+	urlPath := fmt.Sprintf("/kafka/v3/clusters/%s/links/%s/configs", c.ClusterId, linkName)
+	return common.Do[ClusterLinkingConfig](ctx, c.send, &common.Request{
+		Operation: "kafka.GetClusterLinkingConfig",
+		Method:    http.MethodGet,
+		Path:      urlPath,
+	})
 }
 
 func (c *ConfluentClusterClient) CreateMirrorTopics(linkName string, topicName string, mirrorTopicName string) error {
+	return c.CreateMirrorTopicsWithContext(context.Background(), linkName, topicName, mirrorTopicName)
+}
+
+func (c *ConfluentClusterClient) CreateMirrorTopicsWithContext(ctx context.Context, linkName string, topicName string, mirrorTopicName string) error {
 	urlPath := fmt.Sprintf("/kafka/v3/clusters/%s/links/%s/mirrors", c.ClusterId, linkName)
 
 	request := &MirrorTopicReq{
@@ -99,14 +85,10 @@ func (c *ConfluentClusterClient) CreateMirrorTopics(linkName string, topicName s
 		MirrorTopicName: mirrorTopicName,
 	}
 
-	response, err := c.doRequest(c.BaseUrl, urlPath, http.MethodPost, request, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusCreated != response.StatusCode {
-		return fmt.Errorf("failed create mirror topic: %s", response.Status)
-	}
-
-	return nil
+	return common.Exec(ctx, c.send, &common.Request{
+		Operation: "kafka.CreateMirrorTopics",
+		Method:    http.MethodPost,
+		Path:      urlPath,
+		Body:      request,
+	})
 }
@@ -1,6 +1,11 @@
 package cluster
 
-import "github.com/electric-saw/ccloud-client-go/ccloud/common"
+import (
+	"log/slog"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+)
 
 type ConfigOp string
 
@@ -26,6 +31,11 @@ type KafkaConfig struct {
 	} `json:"synonyms"`
 }
 
+// LogValue hides the value of sensitive configs in structured logs.
+func (c KafkaConfig) LogValue() slog.Value {
+	return client.RedactedLogValue(c)
+}
+
 type KafkaConfigList struct {
 	common.BaseModel
 	Data []KafkaConfig `json:"data"`
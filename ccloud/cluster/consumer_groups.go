@@ -1,8 +1,9 @@
 package cluster
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
@@ -25,46 +26,36 @@ type KafkaConsumerGroupList struct {
 	Data []KafkaConsumerGroup `json:"data"`
 }
 
-func (c *ConfluentClusterClient) ListConsumerGroups(opt *common.PaginationOptions) (*KafkaConsumerGroupList, error) {
-	res, err := c.doRequest(c.clusterInfo.ConsumerGroups.Related, "", http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClusterClient) consumerGroups() common.Resource[KafkaConsumerGroup, KafkaConsumerGroupList] {
+	return common.Resource[KafkaConsumerGroup, KafkaConsumerGroupList]{
+		Send: c.sendTo(linkConsumerGroups),
+		Api:  "kafka",
+		Kind: "ConsumerGroup",
 	}
+}
 
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to list consumer groups: %s", res.Status)
-	}
+func (c *ConfluentClusterClient) ListConsumerGroups(opt *common.PaginationOptions) (*KafkaConsumerGroupList, error) {
+	return c.ListConsumerGroupsWithContext(context.Background(), opt)
+}
 
-	defer res.Body.Close()
+func (c *ConfluentClusterClient) ListConsumerGroupsWithContext(ctx context.Context, opt *common.PaginationOptions) (*KafkaConsumerGroupList, error) {
+	return c.consumerGroups().List(ctx, opt)
+}
 
-	var list KafkaConsumerGroupList
-	err = json.NewDecoder(res.Body).Decode(&list)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClusterClient) AllConsumerGroups(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[KafkaConsumerGroup, error] {
+	return c.consumerGroups().All(ctx, opt)
+}
 
-	return &list, nil
+func (c *ConfluentClusterClient) ListAllConsumerGroups(ctx context.Context, opt *common.PaginationOptions) ([]KafkaConsumerGroup, error) {
+	return common.Collect(c.AllConsumerGroups(ctx, opt))
 }
 
 func (c *ConfluentClusterClient) GetConsumerGroup(consumerGroupId string) (*KafkaConsumerGroup, error) {
-	res, err := c.doRequest(c.clusterInfo.ConsumerGroups.Related, consumerGroupId, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to get consumer group: %s", res.Status)
-	}
-
-	defer res.Body.Close()
-
-	var group KafkaConsumerGroup
-	err = json.NewDecoder(res.Body).Decode(&group)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetConsumerGroupWithContext(context.Background(), consumerGroupId)
+}
 
-	return &group, nil
+func (c *ConfluentClusterClient) GetConsumerGroupWithContext(ctx context.Context, consumerGroupId string) (*KafkaConsumerGroup, error) {
+	return c.consumerGroups().Get(ctx, consumerGroupId, nil)
 }
 
 type KafkaConsumerGroupLag struct {
@@ -83,23 +74,13 @@ type KafkaConsumerGroupLag struct {
 }
 
 func (c *ConfluentClusterClient) GetConsumerGroupLag(consumerGroupId string) (*KafkaConsumerGroupLag, error) {
-	urlPath := fmt.Sprintf("%s/lag-summary", consumerGroupId)
-	res, err := c.doRequest(c.clusterInfo.ConsumerGroups.Related, urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to get consumer group lag: %s", res.Status)
-	}
-
-	defer res.Body.Close()
-
-	var lag KafkaConsumerGroupLag
-	err = json.NewDecoder(res.Body).Decode(&lag)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetConsumerGroupLagWithContext(context.Background(), consumerGroupId)
+}
 
-	return &lag, nil
+func (c *ConfluentClusterClient) GetConsumerGroupLagWithContext(ctx context.Context, consumerGroupId string) (*KafkaConsumerGroupLag, error) {
+	return common.Do[KafkaConsumerGroupLag](ctx, c.sendTo(linkConsumerGroups), &common.Request{
+		Operation: "kafka.GetConsumerGroupLag",
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("%s/lag-summary", consumerGroupId),
+	})
 }
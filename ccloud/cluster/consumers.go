@@ -1,8 +1,9 @@
 package cluster
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
@@ -24,49 +25,27 @@ type KafkaConsumerList struct {
 }
 
 func (c *ConfluentClusterClient) ListConsumer(consumerGroupId string) (*KafkaConsumerList, error) {
-	urlPath := fmt.Sprintf("%s/consumers", consumerGroupId)
-
-	res, err := c.doRequest(c.clusterInfo.ConsumerGroups.Related, urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to list consumer: %s", res.Status)
-	}
-
-	defer res.Body.Close()
-
-	var list KafkaConsumerList
-	err = json.NewDecoder(res.Body).Decode(&list)
-	if err != nil {
-		return nil, err
-	}
+	return c.ListConsumerWithContext(context.Background(), consumerGroupId)
+}
 
-	return &list, nil
+func (c *ConfluentClusterClient) ListConsumerWithContext(ctx context.Context, consumerGroupId string) (*KafkaConsumerList, error) {
+	return common.Do[KafkaConsumerList](ctx, c.sendTo(linkConsumerGroups), &common.Request{
+		Operation: "kafka.ListConsumer",
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("%s/consumers", consumerGroupId),
+	})
 }
 
 func (c *ConfluentClusterClient) GetConsumer(consumerGroupId, consumerId string) (*KafkaConsumer, error) {
-	urlPath := fmt.Sprintf("%s/consumers/%s", consumerGroupId, consumerId)
-
-	res, err := c.doRequest(c.clusterInfo.ConsumerGroups.Related, urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to get consumer: %s", res.Status)
-	}
-
-	defer res.Body.Close()
-
-	var consumer KafkaConsumer
-	err = json.NewDecoder(res.Body).Decode(&consumer)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetConsumerWithContext(context.Background(), consumerGroupId, consumerId)
+}
 
-	return &consumer, nil
+func (c *ConfluentClusterClient) GetConsumerWithContext(ctx context.Context, consumerGroupId, consumerId string) (*KafkaConsumer, error) {
+	return common.Do[KafkaConsumer](ctx, c.sendTo(linkConsumerGroups), &common.Request{
+		Operation: "kafka.GetConsumer",
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("%s/consumers/%s", consumerGroupId, consumerId),
+	})
 }
 
 type KafkaConsumerLag struct {
@@ -88,24 +67,28 @@ type KafkaConsumerLagList struct {
 	Data []KafkaConsumerLag `json:"data"`
 }
 
-func (c *ConfluentClusterClient) ListConsumerLag(consumerGroupId string, opt *common.PaginationOptions) (*KafkaConsumerLagList, error) {
-	urlPath := fmt.Sprintf("%s/lags", consumerGroupId)
-	res, err := c.doRequest(c.clusterInfo.ConsumerGroups.Related, urlPath, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClusterClient) consumerLags(consumerGroupId string) common.Resource[KafkaConsumerLag, KafkaConsumerLagList] {
+	return common.Resource[KafkaConsumerLag, KafkaConsumerLagList]{
+		Send:   c.sendTo(linkConsumerGroups),
+		Api:    "kafka",
+		Kind:   "ConsumerLag",
+		Plural: "ConsumerLag",
+		Path:   fmt.Sprintf("%s/lags", consumerGroupId),
 	}
+}
 
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to get consumer group lag: %s", res.Status)
-	}
+func (c *ConfluentClusterClient) ListConsumerLag(consumerGroupId string, opt *common.PaginationOptions) (*KafkaConsumerLagList, error) {
+	return c.ListConsumerLagWithContext(context.Background(), consumerGroupId, opt)
+}
 
-	defer res.Body.Close()
+func (c *ConfluentClusterClient) ListConsumerLagWithContext(ctx context.Context, consumerGroupId string, opt *common.PaginationOptions) (*KafkaConsumerLagList, error) {
+	return c.consumerLags(consumerGroupId).List(ctx, opt)
+}
 
-	var lags KafkaConsumerLagList
-	err = json.NewDecoder(res.Body).Decode(&lags)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClusterClient) AllConsumerLags(ctx context.Context, consumerGroupId string, opt *common.PaginationOptions) iter.Seq2[KafkaConsumerLag, error] {
+	return c.consumerLags(consumerGroupId).All(ctx, opt)
+}
 
-	return &lags, nil
+func (c *ConfluentClusterClient) ListAllConsumerLags(ctx context.Context, consumerGroupId string, opt *common.PaginationOptions) ([]KafkaConsumerLag, error) {
+	return common.Collect(c.AllConsumerLags(ctx, consumerGroupId, opt))
 }
@@ -0,0 +1,126 @@
+package cluster
+
+import (
+	"context"
+	"iter"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+)
+
+// The interfaces below group the ConfluentClusterClient methods by domain, so
+// callers can depend on the part of the Kafka REST API they use and
+// substitute fakes in tests.
+
+// TopicsApi manages topics and their partitions.
+type TopicsApi interface {
+	ListTopics(opts *common.PaginationOptions) (*TopicList, error)
+	ListTopicsWithContext(ctx context.Context, opts *common.PaginationOptions) (*TopicList, error)
+	AllTopics(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[Topic, error]
+	ListAllTopics(ctx context.Context, opt *common.PaginationOptions) ([]Topic, error)
+	GetTopic(topicId string) (*Topic, error)
+	GetTopicWithContext(ctx context.Context, topicId string) (*Topic, error)
+	CreateTopic(req *TopicCreateReq) (*Topic, error)
+	CreateTopicWithContext(ctx context.Context, req *TopicCreateReq) (*Topic, error)
+	DeleteTopic(topicId string) error
+	DeleteTopicWithContext(ctx context.Context, topicId string) error
+	ListPartitions(topicName string) (*KafkaPartitionList, error)
+	ListPartitionsWithContext(ctx context.Context, topicName string) (*KafkaPartitionList, error)
+	GetPartition(topicName string, partitionId int) (*KafkaPartition, error)
+	GetPartitionWithContext(ctx context.Context, topicName string, partitionId int) (*KafkaPartition, error)
+	BulkCreateTopics(ctx context.Context, reqs []*TopicCreateReq, opts ...common.BulkOption) *common.BulkReport[*TopicCreateReq, *Topic]
+	BulkDeleteTopics(ctx context.Context, topicNames []string, opts ...common.BulkOption) *common.BulkReport[string, struct{}]
+}
+
+// TopicConfigsApi manages topic configs.
+type TopicConfigsApi interface {
+	ListTopicConfigs(topicName string, opt *common.PaginationOptions) (*KafkaConfigList, error)
+	ListTopicConfigsWithContext(ctx context.Context, topicName string, opt *common.PaginationOptions) (*KafkaConfigList, error)
+	AllTopicConfigs(ctx context.Context, topicName string, opt *common.PaginationOptions) iter.Seq2[KafkaConfig, error]
+	ListAllTopicConfigs(ctx context.Context, topicName string, opt *common.PaginationOptions) ([]KafkaConfig, error)
+	GetTopicConfig(topicName, configName string) (*KafkaConfig, error)
+	GetTopicConfigWithContext(ctx context.Context, topicName, configName string) (*KafkaConfig, error)
+	UpdateTopicConfig(topicName, configName string, req *KafkaConfigUpdateReq) error
+	UpdateTopicConfigWithContext(ctx context.Context, topicName, configName string, req *KafkaConfigUpdateReq) error
+	UpdateTopicConfigBatch(topicName string, req *KafkaConfigUpdateBatch) error
+	UpdateTopicConfigBatchWithContext(ctx context.Context, topicName string, req *KafkaConfigUpdateBatch) error
+	ResetTopicConfig(topicName, configName string) error
+	ResetTopicConfigWithContext(ctx context.Context, topicName, configName string) error
+}
+
+// KafkaConfigsApi manages cluster wide broker configs.
+type KafkaConfigsApi interface {
+	ListKafkaConfigs(opt *common.PaginationOptions) (*KafkaConfigList, error)
+	ListKafkaConfigsWithContext(ctx context.Context, opt *common.PaginationOptions) (*KafkaConfigList, error)
+	AllKafkaConfigs(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[KafkaConfig, error]
+	ListAllKafkaConfigs(ctx context.Context, opt *common.PaginationOptions) ([]KafkaConfig, error)
+	GetKafkaConfig(configName string) (*KafkaConfig, error)
+	GetKafkaConfigWithContext(ctx context.Context, configName string) (*KafkaConfig, error)
+	UpdateKafkaConfig(configName string, req *KafkaConfigUpdateReq) error
+	UpdateKafkaConfigWithContext(ctx context.Context, configName string, req *KafkaConfigUpdateReq) error
+	UpdateKafkaConfigBatch(req *KafkaConfigUpdateBatch) error
+	UpdateKafkaConfigBatchWithContext(ctx context.Context, req *KafkaConfigUpdateBatch) error
+	ResetKafkaConfig(configName string) error
+	ResetKafkaConfigWithContext(ctx context.Context, configName string) error
+}
+
+// AclsApi manages Kafka ACLs.
+type AclsApi interface {
+	SearchAcls(qry *KafkaAclSearchQry) (*KafkaAclList, error)
+	SearchAclsWithContext(ctx context.Context, qry *KafkaAclSearchQry) (*KafkaAclList, error)
+	AllAcls(ctx context.Context, qry *KafkaAclSearchQry) iter.Seq2[KafkaAcl, error]
+	ListAllAcls(ctx context.Context, qry *KafkaAclSearchQry) ([]KafkaAcl, error)
+	CreateAcl(acl *KafkaAclCreateReq) error
+	CreateAclWithContext(ctx context.Context, acl *KafkaAclCreateReq) error
+	BatchCreateAcls(batch *KafkaAclBatchCreateReq) error
+	BatchCreateAclsWithContext(ctx context.Context, batch *KafkaAclBatchCreateReq) error
+	DeleteAcl(acl *KafkaAcl) error
+	DeleteAclWithContext(ctx context.Context, acl *KafkaAcl) error
+	BulkCreateAcls(ctx context.Context, acls []*KafkaAclCreateReq, opts ...common.BulkOption) *common.BulkReport[*KafkaAclCreateReq, struct{}]
+	BulkDeleteAcls(ctx context.Context, acls []*KafkaAcl, opts ...common.BulkOption) *common.BulkReport[*KafkaAcl, struct{}]
+}
+
+// ConsumerGroupsApi reads consumer groups, their consumers and lag.
+type ConsumerGroupsApi interface {
+	GetConsumerLag(consumerGroupId, topicName string, partitionId int) (*KafkaPartitionConsumerLag, error)
+	GetConsumerLagWithContext(ctx context.Context, consumerGroupId, topicName string, partitionId int) (*KafkaPartitionConsumerLag, error)
+	ListConsumerGroups(opt *common.PaginationOptions) (*KafkaConsumerGroupList, error)
+	ListConsumerGroupsWithContext(ctx context.Context, opt *common.PaginationOptions) (*KafkaConsumerGroupList, error)
+	AllConsumerGroups(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[KafkaConsumerGroup, error]
+	ListAllConsumerGroups(ctx context.Context, opt *common.PaginationOptions) ([]KafkaConsumerGroup, error)
+	GetConsumerGroup(consumerGroupId string) (*KafkaConsumerGroup, error)
+	GetConsumerGroupWithContext(ctx context.Context, consumerGroupId string) (*KafkaConsumerGroup, error)
+	GetConsumerGroupLag(consumerGroupId string) (*KafkaConsumerGroupLag, error)
+	GetConsumerGroupLagWithContext(ctx context.Context, consumerGroupId string) (*KafkaConsumerGroupLag, error)
+	ListConsumer(consumerGroupId string) (*KafkaConsumerList, error)
+	ListConsumerWithContext(ctx context.Context, consumerGroupId string) (*KafkaConsumerList, error)
+	GetConsumer(consumerGroupId, consumerId string) (*KafkaConsumer, error)
+	GetConsumerWithContext(ctx context.Context, consumerGroupId, consumerId string) (*KafkaConsumer, error)
+	ListConsumerLag(consumerGroupId string, opt *common.PaginationOptions) (*KafkaConsumerLagList, error)
+	ListConsumerLagWithContext(ctx context.Context, consumerGroupId string, opt *common.PaginationOptions) (*KafkaConsumerLagList, error)
+	AllConsumerLags(ctx context.Context, consumerGroupId string, opt *common.PaginationOptions) iter.Seq2[KafkaConsumerLag, error]
+	ListAllConsumerLags(ctx context.Context, consumerGroupId string, opt *common.PaginationOptions) ([]KafkaConsumerLag, error)
+}
+
+// ClusterLinksApi manages cluster links and mirror topics.
+type ClusterLinksApi interface {
+	GetClusterLinking() (*ClusterLinking, error)
+	GetClusterLinkingWithContext(ctx context.Context) (*ClusterLinking, error)
+	GetClusterLinkingConfig(linkName string) (*ClusterLinkingConfig, error)
+	GetClusterLinkingConfigWithContext(ctx context.Context, linkName string) (*ClusterLinkingConfig, error)
+	CreateMirrorTopics(linkName string, topicName string, mirrorTopicName string) error
+	CreateMirrorTopicsWithContext(ctx context.Context, linkName string, topicName string, mirrorTopicName string) error
+}
+
+// Client is the full Kafka REST API implemented by ConfluentClusterClient.
+type Client interface {
+	Discover(ctx context.Context) error
+
+	TopicsApi
+	TopicConfigsApi
+	KafkaConfigsApi
+	AclsApi
+	ConsumerGroupsApi
+	ClusterLinksApi
+}
+
+var _ Client = (*ConfluentClusterClient)(nil)
@@ -1,7 +1,7 @@
 package cluster
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 
@@ -23,25 +23,15 @@ type KafkaPartitionConsumerLag struct {
 }
 
 func (c *ConfluentClusterClient) GetConsumerLag(consumerGroupId, topicName string, partitionId int) (*KafkaPartitionConsumerLag, error) {
-	urlPath := fmt.Sprintf("/topics/%s/lags/%s/partitions/%d", consumerGroupId, topicName, partitionId)
-	res, err := c.doRequest(c.clusterInfo.ConsumerGroups.Related, urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to get consumer lag: %s", res.Status)
-	}
-
-	defer res.Body.Close()
-
-	var lag KafkaPartitionConsumerLag
-	err = json.NewDecoder(res.Body).Decode(&lag)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetConsumerLagWithContext(context.Background(), consumerGroupId, topicName, partitionId)
+}
 
-	return &lag, nil
+func (c *ConfluentClusterClient) GetConsumerLagWithContext(ctx context.Context, consumerGroupId, topicName string, partitionId int) (*KafkaPartitionConsumerLag, error) {
+	return common.Do[KafkaPartitionConsumerLag](ctx, c.sendTo(linkConsumerGroups), &common.Request{
+		Operation: "kafka.GetConsumerLag",
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("%s/lags/%s/partitions/%d", consumerGroupId, topicName, partitionId),
+	})
 }
 
 type KafkaPartition struct {
@@ -60,45 +50,25 @@ type KafkaPartitionList struct {
 }
 
 func (c *ConfluentClusterClient) ListPartitions(topicName string) (*KafkaPartitionList, error) {
-	urlPath := fmt.Sprintf("/%s/partitions", topicName)
-	res, err := c.doRequest(c.clusterInfo.Topics.Related, urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to list partitions: %s", res.Status)
-	}
-
-	defer res.Body.Close()
-
-	var partitions KafkaPartitionList
-	err = json.NewDecoder(res.Body).Decode(&partitions)
-	if err != nil {
-		return nil, err
-	}
+	return c.ListPartitionsWithContext(context.Background(), topicName)
+}
 
-	return &partitions, nil
+func (c *ConfluentClusterClient) ListPartitionsWithContext(ctx context.Context, topicName string) (*KafkaPartitionList, error) {
+	return common.Do[KafkaPartitionList](ctx, c.sendTo(linkTopics), &common.Request{
+		Operation: "kafka.ListPartitions",
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("%s/partitions", topicName),
+	})
 }
 
 func (c *ConfluentClusterClient) GetPartition(topicName string, partitionId int) (*KafkaPartition, error) {
-	urlPath := fmt.Sprintf("/%s/partitions/%d", topicName, partitionId)
-	res, err := c.doRequest(c.clusterInfo.Topics.Related, urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to get partition: %s", res.Status)
-	}
-
-	defer res.Body.Close()
-
-	var partition KafkaPartition
-	err = json.NewDecoder(res.Body).Decode(&partition)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetPartitionWithContext(context.Background(), topicName, partitionId)
+}
 
-	return &partition, nil
+func (c *ConfluentClusterClient) GetPartitionWithContext(ctx context.Context, topicName string, partitionId int) (*KafkaPartition, error) {
+	return common.Do[KafkaPartition](ctx, c.sendTo(linkTopics), &common.Request{
+		Operation: "kafka.GetPartition",
+		Method:    http.MethodGet,
+		Path:      fmt.Sprintf("%s/partitions/%d", topicName, partitionId),
+	})
 }
@@ -1,9 +1,8 @@
 package cluster
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
@@ -25,46 +24,36 @@ type TopicList struct {
 	Data []Topic `json:"data"`
 }
 
-func (c *ConfluentClusterClient) ListTopics(opts *common.PaginationOptions) (*TopicList, error) {
-	req, err := c.doRequest(c.clusterInfo.Topics.Related, "", http.MethodGet, nil, opts)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClusterClient) topics() common.Resource[Topic, TopicList] {
+	return common.Resource[Topic, TopicList]{
+		Send: c.sendTo(linkTopics),
+		Api:  "kafka",
+		Kind: "Topic",
 	}
+}
 
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list topics: %s", req.Status)
-	}
+func (c *ConfluentClusterClient) ListTopics(opts *common.PaginationOptions) (*TopicList, error) {
+	return c.ListTopicsWithContext(context.Background(), opts)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClusterClient) ListTopicsWithContext(ctx context.Context, opts *common.PaginationOptions) (*TopicList, error) {
+	return c.topics().List(ctx, opts)
+}
 
-	var topicList TopicList
-	err = json.NewDecoder(req.Body).Decode(&topicList)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClusterClient) AllTopics(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[Topic, error] {
+	return c.topics().All(ctx, opt)
+}
 
-	return &topicList, nil
+func (c *ConfluentClusterClient) ListAllTopics(ctx context.Context, opt *common.PaginationOptions) ([]Topic, error) {
+	return common.Collect(c.AllTopics(ctx, opt))
 }
 
 func (c *ConfluentClusterClient) GetTopic(topicId string) (*Topic, error) {
-	req, err := c.doRequest(c.clusterInfo.Topics.Related, topicId, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get topic: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var topic Topic
-	err = json.NewDecoder(req.Body).Decode(&topic)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetTopicWithContext(context.Background(), topicId)
+}
 
-	return &topic, nil
+func (c *ConfluentClusterClient) GetTopicWithContext(ctx context.Context, topicId string) (*Topic, error) {
+	return c.topics().Get(ctx, topicId, nil)
 }
 
 type TopicCreateReq struct {
@@ -76,35 +65,17 @@ type TopicCreateReq struct {
 }
 
 func (c *ConfluentClusterClient) CreateTopic(req *TopicCreateReq) (*Topic, error) {
-	res, err := c.doRequest(c.clusterInfo.Topics.Related, "", http.MethodPost, req, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusCreated != res.StatusCode {
-		return nil, fmt.Errorf("failed to create topic: %s", res.Status)
-	}
-
-	defer res.Body.Close()
-
-	var topic Topic
-	err = json.NewDecoder(res.Body).Decode(&topic)
-	if err != nil {
-		return nil, err
-	}
+	return c.CreateTopicWithContext(context.Background(), req)
+}
 
-	return &topic, nil
+func (c *ConfluentClusterClient) CreateTopicWithContext(ctx context.Context, req *TopicCreateReq) (*Topic, error) {
+	return c.topics().Create(ctx, req, nil)
 }
 
 func (c *ConfluentClusterClient) DeleteTopic(topicId string) error {
-	req, err := c.doRequest(c.clusterInfo.Topics.Related, topicId, http.MethodDelete, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusNoContent != req.StatusCode {
-		return fmt.Errorf("failed to delete topic: %s", req.Status)
-	}
+	return c.DeleteTopicWithContext(context.Background(), topicId)
+}
 
-	return nil
+func (c *ConfluentClusterClient) DeleteTopicWithContext(ctx context.Context, topicId string) error {
+	return c.topics().Delete(ctx, topicId, nil)
 }
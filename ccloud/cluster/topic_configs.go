@@ -1,99 +1,81 @@
 package cluster
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
 
-func (c *ConfluentClusterClient) ListTopicConfigs(topicName string, opt *common.PaginationOptions) (*KafkaConfigList, error) {
-	path := fmt.Sprintf("%s/configs", topicName)
-
-	req, err := c.doRequest(c.clusterInfo.Topics.Related, path, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClusterClient) topicConfigs(topicName string) common.Resource[KafkaConfig, KafkaConfigList] {
+	return common.Resource[KafkaConfig, KafkaConfigList]{
+		Send: c.sendTo(linkTopics),
+		Api:  "kafka",
+		Kind: "TopicConfig",
+		Path: fmt.Sprintf("%s/configs", topicName),
 	}
+}
 
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list kafka configs: %s", req.Status)
-	}
+func (c *ConfluentClusterClient) ListTopicConfigs(topicName string, opt *common.PaginationOptions) (*KafkaConfigList, error) {
+	return c.ListTopicConfigsWithContext(context.Background(), topicName, opt)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClusterClient) ListTopicConfigsWithContext(ctx context.Context, topicName string, opt *common.PaginationOptions) (*KafkaConfigList, error) {
+	return c.topicConfigs(topicName).List(ctx, opt)
+}
 
-	var configList KafkaConfigList
-	err = json.NewDecoder(req.Body).Decode(&configList)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClusterClient) AllTopicConfigs(ctx context.Context, topicName string, opt *common.PaginationOptions) iter.Seq2[KafkaConfig, error] {
+	return c.topicConfigs(topicName).All(ctx, opt)
+}
 
-	return &configList, nil
+func (c *ConfluentClusterClient) ListAllTopicConfigs(ctx context.Context, topicName string, opt *common.PaginationOptions) ([]KafkaConfig, error) {
+	return common.Collect(c.AllTopicConfigs(ctx, topicName, opt))
 }
 
 func (c *ConfluentClusterClient) GetTopicConfig(topicName, configName string) (*KafkaConfig, error) {
-	path := fmt.Sprintf("%s/configs/%s", topicName, configName)
-
-	req, err := c.doRequest(c.clusterInfo.Topics.Related, path, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get kafka config: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var config KafkaConfig
-	err = json.NewDecoder(req.Body).Decode(&config)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetTopicConfigWithContext(context.Background(), topicName, configName)
+}
 
-	return &config, nil
+func (c *ConfluentClusterClient) GetTopicConfigWithContext(ctx context.Context, topicName, configName string) (*KafkaConfig, error) {
+	return c.topicConfigs(topicName).Get(ctx, configName, nil)
 }
 
 func (c *ConfluentClusterClient) UpdateTopicConfig(topicName, configName string, req *KafkaConfigUpdateReq) error {
-	path := fmt.Sprintf("%s/configs/%s", topicName, configName)
-
-	res, err := c.doRequest(c.clusterInfo.Topics.Related, path, http.MethodPut, req, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusNoContent != res.StatusCode {
-		return fmt.Errorf("failed to update kafka config: %s", res.Status)
-	}
+	return c.UpdateTopicConfigWithContext(context.Background(), topicName, configName, req)
+}
 
-	return nil
+func (c *ConfluentClusterClient) UpdateTopicConfigWithContext(ctx context.Context, topicName, configName string, req *KafkaConfigUpdateReq) error {
+	return common.Exec(ctx, c.sendTo(linkTopics), &common.Request{
+		Operation: "kafka.UpdateTopicConfig",
+		Method:    http.MethodPut,
+		Path:      c.topicConfigs(topicName).ItemPath(configName),
+		Body:      req,
+	})
 }
 
 func (c *ConfluentClusterClient) UpdateTopicConfigBatch(topicName string, req *KafkaConfigUpdateBatch) error {
-	path := fmt.Sprintf("%s/configs:alter", topicName)
-
-	res, err := c.doRequest(c.clusterInfo.Topics.Related, path, http.MethodPost, req, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusNoContent != res.StatusCode {
-		return fmt.Errorf("failed to update kafka config: %s", res.Status)
-	}
+	return c.UpdateTopicConfigBatchWithContext(context.Background(), topicName, req)
+}
 
-	return nil
+func (c *ConfluentClusterClient) UpdateTopicConfigBatchWithContext(ctx context.Context, topicName string, req *KafkaConfigUpdateBatch) error {
+	return common.Exec(ctx, c.sendTo(linkTopics), &common.Request{
+		Operation: "kafka.UpdateTopicConfigBatch",
+		Method:    http.MethodPost,
+		Path:      c.topicConfigs(topicName).Path + ":alter",
+		Body:      req,
+	})
 }
 
 func (c *ConfluentClusterClient) ResetTopicConfig(topicName, configName string) error {
-	path := fmt.Sprintf("%s/configs/%s", topicName, configName)
-	res, err := c.doRequest(c.clusterInfo.Topics.Related, path, http.MethodDelete, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusNoContent != res.StatusCode {
-		return fmt.Errorf("failed to delete kafka config: %s", res.Status)
-	}
+	return c.ResetTopicConfigWithContext(context.Background(), topicName, configName)
+}
 
-	return nil
+func (c *ConfluentClusterClient) ResetTopicConfigWithContext(ctx context.Context, topicName, configName string) error {
+	return common.Exec(ctx, c.sendTo(linkTopics), &common.Request{
+		Operation: "kafka.ResetTopicConfig",
+		Method:    http.MethodDelete,
+		Path:      c.topicConfigs(topicName).ItemPath(configName),
+	})
 }
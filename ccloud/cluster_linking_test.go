@@ -1,19 +1,21 @@
 package ccloud_test
 
 import (
-	"os"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/electric-saw/ccloud-client-go/ccloud"
 	"github.com/electric-saw/ccloud-client-go/ccloud/cluster"
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetClusterLinking(t *testing.T) {
-	err := godotenv.Load()
-	assert.NoError(t, err)
-
-	c, err := makeClusterClient()
+	c, err := makeClusterClient(t)
 	assert.NoError(t, err)
 
 	linking, err := c.GetClusterLinking()
@@ -23,34 +25,66 @@ func TestGetClusterLinking(t *testing.T) {
 }
 
 func TestGetClusterLinkingConfig(t *testing.T) {
-	err := godotenv.Load()
-	assert.NoError(t, err)
-
-	c, err := makeClusterClient()
+	c, err := makeClusterClient(t)
 	assert.NoError(t, err)
 
-	linking, err := c.GetClusterLinkingConfig(os.Getenv("LINK_NAME"))
+	linking, err := c.GetClusterLinkingConfig(testEnv("LINK_NAME", "link-primary"))
 	assert.NoError(t, err)
 
 	assert.NotNil(t, linking)
 }
 
 func TestCreateMirrorTopic(t *testing.T) {
-	err := godotenv.Load()
+	c, err := makeClusterClient(t)
 	assert.NoError(t, err)
 
-	c, err := makeClusterClient()
+	err = c.CreateMirrorTopics(testEnv("LINK_NAME_DR", "link-dr"), testEnv("TOPIC_NAME", "orders"), testEnv("MIRROR_TOPIC_NAME", "orders"))
 	assert.NoError(t, err)
 
-	err = c.CreateMirrorTopics(os.Getenv("LINK_NAME_DR"), os.Getenv("TOPIC_NAME"), os.Getenv("MIRROR_TOPIC_NAME"))
-	assert.NoError(t, err)
+}
+
+func makeClusterClient(t *testing.T) (*cluster.ConfluentClusterClient, error) {
+	_ = godotenv.Load()
 
+	key := testEnv("CLUSTER_USER_DR", "CLUSTERKEY")
+	secret := testEnv("CLUSTER_PASSWORD_DR", "cluster-secret")
+	clusterId := testEnv("CLUSTER_ID_DR", "lkc-dr0001")
+	clusterUrl := testEnv("CLUSTER_URL_DR", "https://pkc-dr0001.us-east-1.aws.confluent.cloud:443")
+	return cluster.NewClusterClient(ccloud.NewBasicAuth(key, secret), clusterId, clusterUrl, newRecorder(t).ClientOptions()...)
 }
 
-func makeClusterClient() (*cluster.ConfluentClusterClient, error) {
-	key := os.Getenv("CLUSTER_USER_DR")
-	secret := os.Getenv("CLUSTER_PASSWORD_DR")
-	clusterId := os.Getenv("CLUSTER_ID_DR")
-	clusterUrl := os.Getenv("CLUSTER_URL_DR")
-	return cluster.NewClusterClient(key, secret, clusterId, clusterUrl)
+func TestClusterLinkingRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /kafka/v3/clusters/lkc-dr0001/links":
+			fmt.Fprint(w, `{"data":[{"link_name":"link-dr","link_state":"ACTIVE","topic_names":["orders"]}]}`)
+		case "GET /kafka/v3/clusters/lkc-dr0001/links/link-dr/configs":
+			fmt.Fprint(w, `{"data":[{"link_name":"link-dr","name":"consumer.offset.sync.enable","value":"true"}]}`)
+		case "POST /kafka/v3/clusters/lkc-dr0001/links/link-dr/mirrors":
+			var req cluster.MirrorTopicReq
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, cluster.MirrorTopicReq{SourceTopicName: "orders", MirrorTopicName: "orders-mirror"}, req)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := cluster.NewClusterClient(ccloud.NewBasicAuth("key", "secret"), "lkc-dr0001", ts.URL)
+	require.NoError(t, err)
+
+	linking, err := c.GetClusterLinking()
+	require.NoError(t, err)
+	require.Len(t, linking.Data, 1)
+	assert.Equal(t, "ACTIVE", linking.Data[0].LinkState)
+
+	config, err := c.GetClusterLinkingConfig("link-dr")
+	require.NoError(t, err)
+	require.Len(t, config.Data, 1)
+	assert.Equal(t, "consumer.offset.sync.enable", config.Data[0].Name)
+
+	assert.NoError(t, c.CreateMirrorTopics("link-dr", "orders", "orders-mirror"))
 }
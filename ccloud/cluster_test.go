@@ -1,15 +1,27 @@
 package ccloud_test
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/cluster"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/credentials"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListCluster(t *testing.T) {
-	c := makeClient()
+	c := makeClient(t)
 
 	environments, err := c.ListEnvironments(&common.PaginationOptions{
 		PageSize: 1})
@@ -34,3 +46,157 @@ func TestListCluster(t *testing.T) {
 	assert.NotNil(t, cluster)
 
 }
+
+func TestListAndGetKafkaCluster(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	envId := srv.AddEnvironment("dev")
+	clusterId, err := srv.AddKafkaCluster(envId, "orders")
+	require.NoError(t, err)
+
+	clusters, err := c.ListKafkaClusters(&ccloud.KafkaClusterListOptions{EnvironmentId: envId})
+	require.NoError(t, err)
+	require.Len(t, clusters.Data, 1)
+	assert.Equal(t, clusterId, clusters.Data[0].Id)
+
+	kafkaCluster, err := c.GetKafkaCluster(clusterId, &ccloud.KafkaClusterListOptions{
+		EnvironmentId: clusters.Data[0].Spec.Environment.Id,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "orders", kafkaCluster.Spec.DisplayName)
+}
+
+func kafkaRestServer(t *testing.T, clusterCalls *int32) *httptest.Server {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		assert.Equal(t, "cluster-key", user)
+
+		switch r.URL.Path {
+		case "/kafka/v3/clusters/lkc-abc123":
+			if atomic.AddInt32(clusterCalls, 1) == 1 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"cluster_id":"lkc-abc123","topics":{"related":"%s/kafka/v3/clusters/lkc-abc123/topics"}}`, ts.URL)
+		case "/kafka/v3/clusters/lkc-abc123/topics":
+			fmt.Fprint(w, `{"data":[{"topic_name":"orders"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return ts
+}
+
+func TestClusterClientLazyDiscovery(t *testing.T) {
+	var clusterCalls int32
+	ts := kafkaRestServer(t, &clusterCalls)
+	defer ts.Close()
+
+	c := ccloud.NewClient(client.WithRetryMax(0))
+	kafkaCluster := &ccloud.KafkaCluster{}
+	kafkaCluster.Id = "lkc-abc123"
+	kafkaCluster.Spec.HttpEndpoint = ts.URL
+
+	provider := credentials.NewStaticProvider().WithResource("lkc-abc123", "cluster-key", "cluster-secret")
+	clusterClient, err := c.NewClusterClientFromCredentials(kafkaCluster, provider)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&clusterCalls))
+
+	// A failed discovery is not cached.
+	_, err = clusterClient.ListTopicsWithContext(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+
+	for i := 0; i < 2; i++ {
+		topics, err := clusterClient.ListTopicsWithContext(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Len(t, topics.Data, 1)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&clusterCalls))
+}
+
+func TestClusterClientSendsToRelatedLinks(t *testing.T) {
+	var requests []string
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/kafka/v3/clusters/lkc-abc123" {
+			fmt.Fprintf(w, `{"cluster_id":"lkc-abc123","acls":{"related":"%[1]s/kafka/v3/clusters/lkc-abc123/acls"},"broker_configs":{"related":"%[1]s/kafka/v3/clusters/lkc-abc123/broker-configs"},"consumer_groups":{"related":"%[1]s/kafka/v3/clusters/lkc-abc123/consumer-groups"}}`, ts.URL)
+			return
+		}
+		requests = append(requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/kafka/v3/clusters/lkc-abc123"))
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	c, err := cluster.NewClusterClient(ccloud.NewBasicAuth("key", "secret"), "lkc-abc123", ts.URL)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, c.BatchCreateAclsWithContext(ctx, &cluster.KafkaAclBatchCreateReq{}))
+	_, err = c.GetKafkaConfigWithContext(ctx, "log.retention.ms")
+	require.NoError(t, err)
+	require.NoError(t, c.UpdateKafkaConfigBatchWithContext(ctx, &cluster.KafkaConfigUpdateBatch{}))
+	require.NoError(t, c.ResetKafkaConfigWithContext(ctx, "log.retention.ms"))
+	_, err = c.GetConsumerGroupWithContext(ctx, "orders-app")
+	require.NoError(t, err)
+	_, err = c.ListConsumerWithContext(ctx, "orders-app")
+	require.NoError(t, err)
+	_, err = c.GetConsumerLagWithContext(ctx, "orders-app", "orders", 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"POST /acls:batch",
+		"GET /broker-configs/log.retention.ms",
+		"POST /broker-configs:alter",
+		"DELETE /broker-configs/log.retention.ms",
+		"GET /consumer-groups/orders-app",
+		"GET /consumer-groups/orders-app/consumers",
+		"GET /consumer-groups/orders-app/lags/orders/partitions/3",
+	}, requests)
+}
+
+func TestNewClusterClientRequiresHttpEndpoint(t *testing.T) {
+	kafkaCluster := &ccloud.KafkaCluster{}
+	kafkaCluster.Id = "lkc-abc123"
+
+	_, err := ccloud.NewClient().NewClusterClient(kafkaCluster, ccloud.NewBasicAuth("key", "secret"))
+	assert.Error(t, err)
+}
+
+func TestClusterClientDiscoveryHonorsContext(t *testing.T) {
+	var clusterCalls int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/kafka/v3/clusters/lkc-abc123" {
+			atomic.AddInt32(&clusterCalls, 1)
+			<-release
+		}
+		fmt.Fprint(w, `{"cluster_id":"lkc-abc123"}`)
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	clusterClient, err := cluster.NewClusterClient(ccloud.NewBasicAuth("key", "secret"), "lkc-abc123", ts.URL)
+	assert.NoError(t, err)
+
+	go func() { _ = clusterClient.Discover(context.Background()) }()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&clusterCalls) == 1 }, time.Second, time.Millisecond)
+
+	// A call waiting on a stuck discovery gives up with its own deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.ErrorIs(t, clusterClient.Discover(ctx), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&clusterCalls), "the discovery is shared")
+}
+
+func TestNewClusterClientRequiresAbsoluteUrl(t *testing.T) {
+	for _, clusterUrl := range []string{"", "pkc-123.confluent.cloud", "/kafka", "https://"} {
+		_, err := cluster.NewClusterClient(ccloud.NewBasicAuth("key", "secret"), "lkc-abc123", clusterUrl)
+		assert.Error(t, err, clusterUrl)
+	}
+}
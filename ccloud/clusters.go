@@ -1,11 +1,14 @@
 package ccloud
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
+	"iter"
 
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/cluster"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/credentials"
 )
 
 type KafkaClusterAvailability string
@@ -58,48 +61,38 @@ type KafkaClusterListOptions struct {
 	EnvironmentId string `url:"environment,omitempty"`
 }
 
-func (c *ConfluentClient) ListKafkaClusters(opt *KafkaClusterListOptions) (*KafkaClusterList, error) {
-	urlPath := "/cmk/v2/clusters"
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClient) kafkaClusters() common.Resource[KafkaCluster, KafkaClusterList] {
+	return common.Resource[KafkaCluster, KafkaClusterList]{
+		Send: c.send,
+		Api:  "cmk",
+		Kind: "KafkaCluster",
+		Path: "/cmk/v2/clusters",
+		Spec: true,
 	}
+}
 
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list kafka clusters: %s", req.Status)
-	}
+func (c *ConfluentClient) ListKafkaClusters(opt *KafkaClusterListOptions) (*KafkaClusterList, error) {
+	return c.ListKafkaClustersWithContext(context.Background(), opt)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClient) ListKafkaClustersWithContext(ctx context.Context, opt *KafkaClusterListOptions) (*KafkaClusterList, error) {
+	return c.kafkaClusters().List(ctx, opt)
+}
 
-	var KafkaClusters KafkaClusterList
-	err = json.NewDecoder(req.Body).Decode(&KafkaClusters)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClient) AllKafkaClusters(ctx context.Context, opt *KafkaClusterListOptions) iter.Seq2[KafkaCluster, error] {
+	return c.kafkaClusters().All(ctx, opt)
+}
 
-	return &KafkaClusters, nil
+func (c *ConfluentClient) ListAllKafkaClusters(ctx context.Context, opt *KafkaClusterListOptions) ([]KafkaCluster, error) {
+	return common.Collect(c.AllKafkaClusters(ctx, opt))
 }
 
 func (c *ConfluentClient) GetKafkaCluster(kafkaClusterId string, opt *KafkaClusterListOptions) (*KafkaCluster, error) {
-	urlPath := fmt.Sprintf("/cmk/v2/clusters/%s", kafkaClusterId)
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get kafka cluster: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var KafkaCluster KafkaCluster
-	err = json.NewDecoder(req.Body).Decode(&KafkaCluster)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetKafkaClusterWithContext(context.Background(), kafkaClusterId, opt)
+}
 
-	return &KafkaCluster, nil
+func (c *ConfluentClient) GetKafkaClusterWithContext(ctx context.Context, kafkaClusterId string, opt *KafkaClusterListOptions) (*KafkaCluster, error) {
+	return c.kafkaClusters().Get(ctx, kafkaClusterId, opt)
 }
 
 type KafkaClusterCreateReq struct {
@@ -118,25 +111,11 @@ type KafkaClusterCreateReq struct {
 }
 
 func (c *ConfluentClient) CreateKafkaCluster(create *KafkaClusterCreateReq) (*KafkaCluster, error) {
-	urlPath := "/cmk/v2/clusters"
-	req, err := c.doRequest(urlPath, http.MethodPost, specWrap{create}, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusAccepted != req.StatusCode {
-		return nil, fmt.Errorf("failed to create cluster: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var KafkaCluster KafkaCluster
-	err = json.NewDecoder(req.Body).Decode(&KafkaCluster)
-	if err != nil {
-		return nil, err
-	}
+	return c.CreateKafkaClusterWithContext(context.Background(), create)
+}
 
-	return &KafkaCluster, nil
+func (c *ConfluentClient) CreateKafkaClusterWithContext(ctx context.Context, create *KafkaClusterCreateReq) (*KafkaCluster, error) {
+	return c.kafkaClusters().Create(ctx, create, nil)
 }
 
 type KafkaClusterUpdateReq struct {
@@ -152,37 +131,50 @@ type KafkaClusterUpdateReq struct {
 }
 
 func (c *ConfluentClient) UpdateKafkaCluster(kafkaClusterId string, update *KafkaClusterUpdateReq) (*KafkaCluster, error) {
-	urlPath := fmt.Sprintf("/cmk/v2/clusters/%s", kafkaClusterId)
-	req, err := c.doRequest(urlPath, http.MethodPatch, specWrap{update}, nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.UpdateKafkaClusterWithContext(context.Background(), kafkaClusterId, update)
+}
 
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to update kafka cluster: %s", req.Status)
-	}
+func (c *ConfluentClient) UpdateKafkaClusterWithContext(ctx context.Context, kafkaClusterId string, update *KafkaClusterUpdateReq) (*KafkaCluster, error) {
+	return c.kafkaClusters().Update(ctx, kafkaClusterId, update, nil)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClient) DeleteKafkaCluster(kafkaClusterId string, opt KafkaClusterListOptions) error {
+	return c.DeleteKafkaClusterWithContext(context.Background(), kafkaClusterId, opt)
+}
 
-	var KafkaCluster KafkaCluster
-	err = json.NewDecoder(req.Body).Decode(&KafkaCluster)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClient) DeleteKafkaClusterWithContext(ctx context.Context, kafkaClusterId string, opt KafkaClusterListOptions) error {
+	return c.kafkaClusters().Delete(ctx, kafkaClusterId, opt)
+}
+
+// NewClusterClient builds a client for the Kafka REST API of a cluster
+// returned by the cmk API, using its http endpoint and a cluster scoped
+// credential. The client starts from the options of c, opts are applied on
+// top. The cluster is discovered lazily, on first use.
+func (c *ConfluentClient) NewClusterClient(kafkaCluster *KafkaCluster, auth client.ClientAuth, opts ...client.Option) (*cluster.ConfluentClusterClient, error) {
+	if kafkaCluster == nil {
+		return nil, fmt.Errorf("kafka cluster is required")
 	}
 
-	return &KafkaCluster, nil
+	if kafkaCluster.Spec.HttpEndpoint == "" {
+		return nil, fmt.Errorf("kafka cluster %s has no http endpoint", kafkaCluster.Id)
+	}
+
+	opts = append([]client.Option{client.WithOptions(c.http.Options())}, opts...)
+
+	return cluster.NewClusterClient(auth, kafkaCluster.Id, kafkaCluster.Spec.HttpEndpoint, opts...)
 }
 
-func (c *ConfluentClient) DeleteKafkaCluster(kafkaClusterId string, opt KafkaClusterListOptions) error {
-	urlPath := fmt.Sprintf("/cmk/v2/clusters/%s", kafkaClusterId)
-	req, err := c.doRequest(urlPath, http.MethodDelete, nil, opt)
-	if err != nil {
-		return err
+// NewClusterClientFromCredentials is like NewClusterClient, resolving the
+// credential of the cluster from provider.
+func (c *ConfluentClient) NewClusterClientFromCredentials(kafkaCluster *KafkaCluster, provider credentials.Provider, opts ...client.Option) (*cluster.ConfluentClusterClient, error) {
+	if kafkaCluster == nil {
+		return nil, fmt.Errorf("kafka cluster is required")
 	}
 
-	if http.StatusOK != req.StatusCode && http.StatusNoContent != req.StatusCode {
-		return fmt.Errorf("failed to delete kafka cluster: %s", req.Status)
+	auth, err := credentials.Auth(provider, kafkaCluster.Id)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return c.NewClusterClient(kafkaCluster, auth, opts...)
 }
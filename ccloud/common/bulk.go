@@ -0,0 +1,178 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const DefaultBulkConcurrency = 8
+
+// ErrBulkSkipped is wrapped by the result of every item that was not run,
+// because an earlier item failed in fail-fast mode or the context is done.
+var ErrBulkSkipped = errors.New("skipped")
+
+// BulkOptions configures Bulk.
+type BulkOptions struct {
+	// Concurrency bounds the operations in flight. Requests still go through
+	// the rate limiter of the client, if any.
+	Concurrency int
+	// FailFast stops scheduling new operations after the first failure.
+	FailFast bool
+	// Progress is called after every item, with the number of items done.
+	Progress func(done, total int)
+}
+
+type BulkOption func(*BulkOptions)
+
+func NewBulkOptions(opts ...BulkOption) *BulkOptions {
+	options := &BulkOptions{Concurrency: DefaultBulkConcurrency}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.Concurrency < 1 {
+		options.Concurrency = 1
+	}
+
+	return options
+}
+
+func WithConcurrency(concurrency int) BulkOption {
+	return func(o *BulkOptions) {
+		o.Concurrency = concurrency
+	}
+}
+
+func WithFailFast() BulkOption {
+	return func(o *BulkOptions) {
+		o.FailFast = true
+	}
+}
+
+func WithBulkProgress(progress func(done, total int)) BulkOption {
+	return func(o *BulkOptions) {
+		o.Progress = progress
+	}
+}
+
+// BulkResult is the outcome of a single item.
+type BulkResult[T, R any] struct {
+	Index int
+	Item  T
+	Value R
+	Err   error
+}
+
+func (r BulkResult[T, R]) Skipped() bool {
+	return errors.Is(r.Err, ErrBulkSkipped)
+}
+
+// BulkReport holds a result per item, in the order of the items.
+type BulkReport[T, R any] struct {
+	Results []BulkResult[T, R]
+}
+
+func (r *BulkReport[T, R]) filter(keep func(BulkResult[T, R]) bool) []BulkResult[T, R] {
+	var results []BulkResult[T, R]
+	for _, result := range r.Results {
+		if keep(result) {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+func (r *BulkReport[T, R]) Succeeded() []BulkResult[T, R] {
+	return r.filter(func(result BulkResult[T, R]) bool { return result.Err == nil })
+}
+
+// Failed returns the items that were run and failed.
+func (r *BulkReport[T, R]) Failed() []BulkResult[T, R] {
+	return r.filter(func(result BulkResult[T, R]) bool { return result.Err != nil && !result.Skipped() })
+}
+
+func (r *BulkReport[T, R]) Skipped() []BulkResult[T, R] {
+	return r.filter(func(result BulkResult[T, R]) bool { return result.Skipped() })
+}
+
+// Err joins the errors of the failed items, or returns the reason items were
+// skipped when none failed. It is nil when every item succeeded.
+func (r *BulkReport[T, R]) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("item %d: %w", result.Index, result.Err))
+	}
+
+	if skipped := r.Skipped(); len(errs) == 0 && len(skipped) > 0 {
+		return fmt.Errorf("%d items: %w", len(skipped), skipped[0].Err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Bulk runs fn for every item with a bounded number of goroutines and
+// reports the outcome of each one.
+func Bulk[T, R any](ctx context.Context, items []T, fn func(ctx context.Context, item T) (R, error), opts ...BulkOption) *BulkReport[T, R] {
+	options := NewBulkOptions(opts...)
+
+	// Stopping only prevents new items from starting, those in flight are
+	// left to complete.
+	stop, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	report := &BulkReport[T, R]{Results: make([]BulkResult[T, R], len(items))}
+
+	indexes := make(chan int)
+	go func() {
+		defer close(indexes)
+		for i := range items {
+			indexes <- i
+		}
+	}()
+
+	var mu sync.Mutex
+	done := 0
+
+	var wg sync.WaitGroup
+	for range min(options.Concurrency, len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				result := BulkResult[T, R]{Index: i, Item: items[i]}
+
+				if stop.Err() != nil {
+					result.Err = fmt.Errorf("%w: %w", ErrBulkSkipped, context.Cause(stop))
+				} else {
+					result.Value, result.Err = fn(ctx, items[i])
+					if result.Err != nil && options.FailFast {
+						cancel(fmt.Errorf("item %d failed: %w", i, result.Err))
+					}
+				}
+
+				report.Results[i] = result
+
+				mu.Lock()
+				done++
+				if options.Progress != nil {
+					options.Progress(done, len(items))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+// BulkDo is Bulk for operations without a result, e.g. deletes.
+func BulkDo[T any](ctx context.Context, items []T, fn func(ctx context.Context, item T) error, opts ...BulkOption) *BulkReport[T, struct{}] {
+	return Bulk(ctx, items, func(ctx context.Context, item T) (struct{}, error) {
+		return struct{}{}, fn(ctx, item)
+	}, opts...)
+}
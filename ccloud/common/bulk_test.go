@@ -0,0 +1,89 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulk(t *testing.T) {
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	var progress atomic.Int32
+
+	report := common.Bulk(context.Background(), items, func(ctx context.Context, item int) (string, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if n <= peak || maxInFlight.CompareAndSwap(peak, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		if item%10 == 3 {
+			return "", fmt.Errorf("item %d is invalid", item)
+		}
+		return fmt.Sprint(item), nil
+	}, common.WithConcurrency(4), common.WithBulkProgress(func(done, total int) {
+		progress.Store(int32(done))
+		assert.Equal(t, 50, total)
+	}))
+
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(4))
+	assert.Equal(t, int32(50), progress.Load())
+
+	assert.Len(t, report.Results, 50)
+	assert.Len(t, report.Succeeded(), 45)
+	assert.Len(t, report.Failed(), 5)
+	assert.Empty(t, report.Skipped())
+
+	for i, result := range report.Results {
+		assert.Equal(t, i, result.Index)
+		assert.Equal(t, i, result.Item)
+	}
+	assert.Equal(t, "12", report.Results[12].Value)
+	assert.ErrorContains(t, report.Err(), "item 13: item 13 is invalid")
+}
+
+func TestBulkFailFast(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+
+	report := common.BulkDo(context.Background(), items, func(ctx context.Context, item string) error {
+		if item == "b" {
+			return common.ErrConflict
+		}
+		return nil
+	}, common.WithConcurrency(1), common.WithFailFast())
+
+	assert.NoError(t, report.Results[0].Err)
+	assert.ErrorIs(t, report.Results[1].Err, common.ErrConflict)
+	assert.True(t, report.Results[2].Skipped())
+	assert.True(t, report.Results[3].Skipped())
+	assert.Len(t, report.Failed(), 1)
+	assert.ErrorIs(t, report.Err(), common.ErrConflict)
+}
+
+func TestBulkCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := common.BulkDo(ctx, []string{"a", "b"}, func(ctx context.Context, item string) error {
+		return errors.New("unexpected call")
+	})
+
+	assert.Len(t, report.Skipped(), 2)
+	assert.ErrorIs(t, report.Err(), common.ErrBulkSkipped)
+	assert.ErrorIs(t, report.Err(), context.Canceled)
+}
@@ -48,13 +48,21 @@ type ErrorResponse struct {
 
 func (b *BaseModel) GetPageNextToken() string {
 	if b.Metadata.Next != nil {
-		u, _ := url.Parse(*b.Metadata.Next)
-		return u.Query().Get("page_token")
+		return PageTokenFromUrl(*b.Metadata.Next)
 	} else {
 		return ""
 	}
 }
 
+// PageTokenFromUrl extracts the page_token query parameter of a metadata.next link.
+func PageTokenFromUrl(next string) string {
+	u, err := url.Parse(next)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("page_token")
+}
+
 func (er *ErrorResponse) Error() string {
 	return er.Message
 }
@@ -0,0 +1,166 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation failed")
+)
+
+// Kafka REST v3 reports some conflicts as 400 Bad Request with a dedicated
+// error code, e.g. creating a topic that already exists.
+const kafkaRestErrorCodeAlreadyExists = 40002
+
+const maxErrorBodySize = 1 << 20
+
+// APIError is returned whenever Confluent Cloud or a Kafka REST endpoint
+// answers with an unexpected status. It can be matched with errors.Is against
+// the sentinel errors of this package and inspected with errors.As.
+type APIError struct {
+	StatusCode int
+	Status     string
+	ErrorCode  int
+	Code       string
+	Message    string
+	RequestId  string
+	Method     string
+	Endpoint   string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Status)
+	if e.Method != "" || e.Endpoint != "" {
+		fmt.Fprintf(&b, " (%s %s)", e.Method, e.Endpoint)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.RequestId != "" {
+		fmt.Fprintf(&b, " [request_id=%s]", e.RequestId)
+	}
+	return b.String()
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || e.ErrorCode == kafkaRestErrorCodeAlreadyExists
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return (e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity) &&
+			e.ErrorCode != kafkaRestErrorCodeAlreadyExists
+	}
+	return false
+}
+
+// errorBody covers the error shapes used across the Confluent APIs: the
+// JSON:API style "errors" list of the v2 APIs, the flat Kafka REST v3 body and
+// the nested object returned by the connect API.
+type errorBody struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+	RequestId string `json:"request_id"`
+	Errors    []struct {
+		Id     string `json:"id"`
+		Status string `json:"status"`
+		Code   string `json:"code"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+	Error json.RawMessage `json:"error"`
+}
+
+// NewAPIError builds an APIError from a response, consuming and closing its body.
+func NewAPIError(res *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		RequestId:  res.Header.Get("X-Request-Id"),
+	}
+
+	if apiErr.Status == "" {
+		apiErr.Status = fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
+	}
+
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		if res.Request.URL != nil {
+			apiErr.Endpoint = res.Request.URL.Path
+		}
+	}
+
+	if res.Body == nil {
+		return apiErr
+	}
+	defer res.Body.Close()
+
+	buff, err := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	if err != nil || len(buff) == 0 {
+		return apiErr
+	}
+	apiErr.Body = buff
+
+	var body errorBody
+	if err := json.Unmarshal(buff, &body); err != nil {
+		apiErr.Message = strings.TrimSpace(string(buff))
+		return apiErr
+	}
+
+	apiErr.ErrorCode = body.ErrorCode
+	apiErr.Message = body.Message
+	if apiErr.RequestId == "" {
+		apiErr.RequestId = body.RequestId
+	}
+
+	if len(body.Errors) > 0 {
+		first := body.Errors[0]
+		apiErr.Code = first.Code
+		apiErr.Message = first.Detail
+		if apiErr.Message == "" {
+			apiErr.Message = first.Title
+		}
+		if apiErr.RequestId == "" {
+			apiErr.RequestId = first.Id
+		}
+	}
+
+	if len(body.Error) > 0 {
+		var nested struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		var text string
+		if err := json.Unmarshal(body.Error, &nested); err == nil {
+			apiErr.ErrorCode = nested.Code
+			apiErr.Message = nested.Message
+		} else if err := json.Unmarshal(body.Error, &text); err == nil {
+			apiErr.Message = text
+		}
+	}
+
+	if apiErr.Message == "" && apiErr.Code == "" && apiErr.ErrorCode == 0 {
+		apiErr.Message = strings.TrimSpace(string(buff))
+	}
+
+	return apiErr
+}
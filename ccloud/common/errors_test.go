@@ -0,0 +1,110 @@
+package common_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func newResponse(status int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request: &http.Request{
+			Method: http.MethodGet,
+			URL:    &url.URL{Path: "/org/v2/environments/env-1"},
+		},
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		header    http.Header
+		sentinel  error
+		errorCode int
+		code      string
+		message   string
+		requestId string
+	}{
+		{
+			name:      "v2 errors list",
+			status:    http.StatusNotFound,
+			body:      `{"errors":[{"id":"req-1","status":"404","code":"resource_not_found","detail":"environment env-1 not found"}]}`,
+			sentinel:  common.ErrNotFound,
+			code:      "resource_not_found",
+			message:   "environment env-1 not found",
+			requestId: "req-1",
+		},
+		{
+			name:      "kafka rest already exists",
+			status:    http.StatusBadRequest,
+			body:      `{"error_code":40002,"message":"Topic 'orders' already exists."}`,
+			sentinel:  common.ErrConflict,
+			errorCode: 40002,
+			message:   "Topic 'orders' already exists.",
+		},
+		{
+			name:      "connect nested error",
+			status:    http.StatusBadRequest,
+			body:      `{"error":{"code":400,"message":"missing required configuration"}}`,
+			sentinel:  common.ErrValidation,
+			errorCode: 400,
+			message:   "missing required configuration",
+		},
+		{
+			name:      "plain text body with request id header",
+			status:    http.StatusTooManyRequests,
+			body:      "slow down",
+			header:    http.Header{"X-Request-Id": []string{"abc"}},
+			sentinel:  common.ErrRateLimited,
+			message:   "slow down",
+			requestId: "abc",
+		},
+		{
+			name:     "unauthorized without body",
+			status:   http.StatusUnauthorized,
+			sentinel: common.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := common.NewAPIError(newResponse(tt.status, tt.body, tt.header))
+
+			assert.Equal(t, tt.status, apiErr.StatusCode)
+			assert.Equal(t, tt.errorCode, apiErr.ErrorCode)
+			assert.Equal(t, tt.code, apiErr.Code)
+			assert.Equal(t, tt.message, apiErr.Message)
+			assert.Equal(t, tt.requestId, apiErr.RequestId)
+			assert.Equal(t, http.MethodGet, apiErr.Method)
+			assert.Equal(t, "/org/v2/environments/env-1", apiErr.Endpoint)
+			assert.ErrorIs(t, apiErr, tt.sentinel)
+		})
+	}
+}
+
+func TestAPIErrorWrapped(t *testing.T) {
+	err := fmt.Errorf("failed to create environment: %w", common.NewAPIError(newResponse(http.StatusConflict, "", nil)))
+
+	assert.ErrorIs(t, err, common.ErrConflict)
+	assert.NotErrorIs(t, err, common.ErrNotFound)
+
+	var apiErr *common.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"iter"
+	"reflect"
+)
+
+// Paged is the query of a paginated list, every query embedding
+// PaginationOptions is one.
+type Paged interface {
+	pagination() *PaginationOptions
+}
+
+func (o *PaginationOptions) pagination() *PaginationOptions {
+	return o
+}
+
+// clonePaged copies query, so that paging never changes the query of the
+// caller. A nil pointer becomes a zero query of its type.
+func clonePaged(query Paged) Paged {
+	if query == nil {
+		return &PaginationOptions{}
+	}
+	v := reflect.ValueOf(query)
+	clone := reflect.New(v.Type().Elem())
+	if !v.IsNil() {
+		clone.Elem().Set(v.Elem())
+	}
+	return clone.Interface().(Paged)
+}
+
+// PageFetcher retrieves the page identified by pageToken, returning its items
+// and the token of the following page, empty on the last one.
+type PageFetcher[T any] func(ctx context.Context, pageToken string) ([]T, string, error)
+
+// Paginate walks every page starting at pageToken, yielding items one by one.
+// Iteration stops at the first error, which is yielded with a zero item, or
+// as soon as the consumer breaks out of the loop.
+func Paginate[T any](ctx context.Context, pageToken string, fetch PageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			items, next, err := fetch(ctx, pageToken)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			// A repeated token would loop forever.
+			if next == "" || next == pageToken {
+				return
+			}
+			pageToken = next
+		}
+	}
+}
+
+// Collect drains a paginated sequence into a slice.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var result []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
@@ -0,0 +1,234 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// Request is a call to the API made through Do, Exec or a Resource.
+type Request struct {
+	// Operation names the call, e.g. "org.GetEnvironment". It also names the
+	// call in errors, e.g. "failed to get environment".
+	Operation string
+	Method    string
+	// Base replaces the base url of the client, e.g. with a related link.
+	Base   string
+	Path   string
+	Body   any
+	Params any
+}
+
+// Sender sends a request, e.g. through the HTTP client of a ConfluentClient.
+type Sender func(ctx context.Context, req *Request) (*http.Response, error)
+
+// Do sends req and decodes a successful response into a new V. Every 2xx
+// status is a success; an empty body leaves V zero. Other statuses are
+// returned as *APIError. The body is always drained and closed.
+func Do[V any](ctx context.Context, send Sender, req *Request) (*V, error) {
+	res, err := send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer drain(res)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to %s: %w", describe(req.Operation), NewAPIError(res))
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s: failed to read response body: %w", describe(req.Operation), err)
+	}
+
+	var value V
+	if len(bytes.TrimSpace(body)) == 0 {
+		return &value, nil
+	}
+
+	// Like the decoders it replaced, this ignores anything after the value.
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to %s: failed to decode response: %w", describe(req.Operation), err)
+	}
+
+	return &value, nil
+}
+
+// Exec is Do for calls whose response body is not needed.
+func Exec(ctx context.Context, send Sender, req *Request) error {
+	res, err := send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer drain(res)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("failed to %s: %w", describe(req.Operation), NewAPIError(res))
+	}
+
+	return nil
+}
+
+// drain reads what is left of the body so the connection can be reused.
+func drain(res *http.Response) {
+	if res.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+}
+
+// describe turns an operation into words, e.g. "iam.ListApiKeys" into
+// "list api keys".
+func describe(operation string) string {
+	if i := strings.LastIndex(operation, "."); i >= 0 {
+		operation = operation[i+1:]
+	}
+
+	var words []string
+	start := 0
+	runes := []rune(operation)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(runes[start:]))
+
+	return strings.ToLower(strings.Join(words, " "))
+}
+
+type specWrap struct {
+	Spec any `json:"spec"`
+}
+
+// Resource declares the CRUD calls of a collection with items of type T,
+// listed as pages of type L, so that every endpoint built on it behaves the
+// same way, see Do.
+type Resource[T, L any] struct {
+	Send Sender
+	// Api prefixes the operation names, e.g. "org".
+	Api string
+	// Kind and Plural complete the operation names, e.g. "Environment" and
+	// "Environments" for org.GetEnvironment and org.ListEnvironments. Plural
+	// defaults to Kind followed by "s".
+	Kind   string
+	Plural string
+	// Base replaces the base url of the client, see Request.
+	Base string
+	// Path is the path of the collection, items are found at Path/{id}.
+	Path string
+	// Spec wraps the bodies of creates and updates in {"spec": ...}.
+	Spec bool
+}
+
+func (r Resource[T, L]) operation(verb string, plural bool) string {
+	name := r.Kind
+	if plural {
+		name = r.Plural
+		if name == "" {
+			name = r.Kind + "s"
+		}
+	}
+	return r.Api + "." + verb + name
+}
+
+func (r Resource[T, L]) body(body any) any {
+	if r.Spec && body != nil {
+		return specWrap{Spec: body}
+	}
+	return body
+}
+
+// ItemPath returns the path of the item with the given id.
+func (r Resource[T, L]) ItemPath(id string) string {
+	return r.Path + "/" + id
+}
+
+func (r Resource[T, L]) List(ctx context.Context, params any) (*L, error) {
+	return Do[L](ctx, r.Send, &Request{
+		Operation: r.operation("List", true),
+		Method:    http.MethodGet,
+		Base:      r.Base,
+		Path:      r.Path,
+		Params:    params,
+	})
+}
+
+// page is the part of every list response All needs.
+type page[T any] struct {
+	BaseModel
+	Data []T `json:"data"`
+}
+
+// All walks every page of the list matching query, see Paginate. query is
+// not modified; it may be nil.
+func (r Resource[T, L]) All(ctx context.Context, query Paged) iter.Seq2[T, error] {
+	query = clonePaged(query)
+	options := query.pagination()
+
+	return Paginate(ctx, options.PageToken, func(ctx context.Context, pageToken string) ([]T, string, error) {
+		options.PageToken = pageToken
+		list, err := Do[page[T]](ctx, r.Send, &Request{
+			Operation: r.operation("List", true),
+			Method:    http.MethodGet,
+			Base:      r.Base,
+			Path:      r.Path,
+			Params:    query,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Data, list.GetPageNextToken(), nil
+	})
+}
+
+func (r Resource[T, L]) Get(ctx context.Context, id string, params any) (*T, error) {
+	return Do[T](ctx, r.Send, &Request{
+		Operation: r.operation("Get", false),
+		Method:    http.MethodGet,
+		Base:      r.Base,
+		Path:      r.ItemPath(id),
+		Params:    params,
+	})
+}
+
+func (r Resource[T, L]) Create(ctx context.Context, body, params any) (*T, error) {
+	return Do[T](ctx, r.Send, &Request{
+		Operation: r.operation("Create", false),
+		Method:    http.MethodPost,
+		Base:      r.Base,
+		Path:      r.Path,
+		Body:      r.body(body),
+		Params:    params,
+	})
+}
+
+// Update patches the item with the given id.
+func (r Resource[T, L]) Update(ctx context.Context, id string, body, params any) (*T, error) {
+	return Do[T](ctx, r.Send, &Request{
+		Operation: r.operation("Update", false),
+		Method:    http.MethodPatch,
+		Base:      r.Base,
+		Path:      r.ItemPath(id),
+		Body:      r.body(body),
+		Params:    params,
+	})
+}
+
+func (r Resource[T, L]) Delete(ctx context.Context, id string, params any) error {
+	return Exec(ctx, r.Send, &Request{
+		Operation: r.operation("Delete", false),
+		Method:    http.MethodDelete,
+		Base:      r.Base,
+		Path:      r.ItemPath(id),
+		Params:    params,
+	})
+}
@@ -0,0 +1,206 @@
+package common_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type widget struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type widgetList struct {
+	Data []widget `json:"data"`
+}
+
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+type recorder struct {
+	requests []*common.Request
+	bodies   []string
+	status   int
+	body     string
+	tracked  []*trackedBody
+}
+
+func (r *recorder) send(ctx context.Context, req *common.Request) (*http.Response, error) {
+	r.requests = append(r.requests, req)
+
+	body := ""
+	if req.Body != nil {
+		data, err := json.Marshal(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(data)
+	}
+	r.bodies = append(r.bodies, body)
+
+	tracked := &trackedBody{Reader: strings.NewReader(r.body)}
+	r.tracked = append(r.tracked, tracked)
+
+	return &http.Response{
+		StatusCode: r.status,
+		Status:     http.StatusText(r.status),
+		Header:     http.Header{},
+		Body:       tracked,
+		Request:    httptest.NewRequest(req.Method, "http://localhost"+req.Path, nil),
+	}, nil
+}
+
+func TestResourceOperations(t *testing.T) {
+	rec := &recorder{status: http.StatusOK, body: `{"id":"w-1","name":"one"}`}
+	widgets := common.Resource[widget, widgetList]{
+		Send: rec.send,
+		Api:  "test",
+		Kind: "Widget",
+		Path: "/test/v1/widgets",
+		Spec: true,
+	}
+
+	ctx := context.Background()
+
+	_, err := widgets.List(ctx, nil)
+	require.NoError(t, err)
+	w, err := widgets.Get(ctx, "w-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "one", w.Name)
+	_, err = widgets.Create(ctx, widget{Name: "one"}, nil)
+	require.NoError(t, err)
+	_, err = widgets.Update(ctx, "w-1", widget{Name: "two"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, widgets.Delete(ctx, "w-1", nil))
+
+	expected := []struct {
+		operation, method, path, body string
+	}{
+		{"test.ListWidgets", http.MethodGet, "/test/v1/widgets", ""},
+		{"test.GetWidget", http.MethodGet, "/test/v1/widgets/w-1", ""},
+		{"test.CreateWidget", http.MethodPost, "/test/v1/widgets", `{"spec":{"name":"one"}}`},
+		{"test.UpdateWidget", http.MethodPatch, "/test/v1/widgets/w-1", `{"spec":{"name":"two"}}`},
+		{"test.DeleteWidget", http.MethodDelete, "/test/v1/widgets/w-1", ""},
+	}
+
+	require.Len(t, rec.requests, len(expected))
+	for i, e := range expected {
+		assert.Equal(t, e.operation, rec.requests[i].Operation)
+		assert.Equal(t, e.method, rec.requests[i].Method)
+		assert.Equal(t, e.path, rec.requests[i].Path)
+		assert.Equal(t, e.body, rec.bodies[i])
+		assert.True(t, rec.tracked[i].closed)
+	}
+}
+
+type widgetQuery struct {
+	common.PaginationOptions
+	Color string `url:"color,omitempty"`
+}
+
+func TestResourceAll(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"data":[{"id":"w-1"},{"id":"w-2"}],"metadata":{"next":"http://localhost/test/v1/widgets?page_token=p2"}}`,
+		"p2": `{"data":[{"id":"w-3"}],"metadata":{}}`,
+	}
+
+	var queries []widgetQuery
+	widgets := common.Resource[widget, widgetList]{
+		Send: func(ctx context.Context, req *common.Request) (*http.Response, error) {
+			query := *req.Params.(*widgetQuery)
+			queries = append(queries, query)
+			return (&recorder{status: http.StatusOK, body: pages[query.PageToken]}).send(ctx, req)
+		},
+		Api:  "test",
+		Kind: "Widget",
+		Path: "/test/v1/widgets",
+	}
+
+	query := &widgetQuery{Color: "red"}
+	all, err := common.Collect(widgets.All(context.Background(), query))
+	require.NoError(t, err)
+	assert.Equal(t, []widget{{Id: "w-1"}, {Id: "w-2"}, {Id: "w-3"}}, all)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, "red", queries[1].Color)
+	assert.Equal(t, "p2", queries[1].PageToken)
+	assert.Equal(t, &widgetQuery{Color: "red"}, query, "the query of the caller is left alone")
+
+	var nilQuery *widgetQuery
+	for _, err := range widgets.All(context.Background(), nilQuery) {
+		require.NoError(t, err)
+		break
+	}
+}
+
+func TestDoStatuses(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "ok", status: http.StatusOK, body: `{"id":"w-1"}`},
+		{name: "created", status: http.StatusCreated, body: `{"id":"w-1"}`},
+		{name: "accepted", status: http.StatusAccepted, body: `{"id":"w-1"}`},
+		{name: "no content", status: http.StatusNoContent},
+		{name: "not found", status: http.StatusNotFound, body: `{"error_code":404,"message":"gone"}`, wantErr: "failed to get widget"},
+		{name: "bad json", status: http.StatusOK, body: `{`, wantErr: "failed to get widget: failed to decode response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{status: tt.status, body: tt.body}
+
+			w, err := common.Do[widget](context.Background(), rec.send, &common.Request{
+				Operation: "test.GetWidget",
+				Method:    http.MethodGet,
+				Path:      "/test/v1/widgets/w-1",
+			})
+
+			assert.True(t, rec.tracked[0].closed)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, w)
+			if tt.body != "" {
+				assert.Equal(t, "w-1", w.Id)
+			}
+		})
+	}
+}
+
+func TestExecWrapsAPIError(t *testing.T) {
+	rec := &recorder{status: http.StatusConflict, body: `{"error_code":409,"message":"exists"}`}
+
+	err := common.Exec(context.Background(), rec.send, &common.Request{
+		Operation: "iam.DeleteApiKey",
+		Method:    http.MethodDelete,
+		Path:      "/iam/v2/api-keys/k-1",
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, common.ErrConflict)
+	assert.Contains(t, err.Error(), "failed to delete api key")
+	assert.True(t, rec.tracked[0].closed)
+}
@@ -0,0 +1,192 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultWaitInterval    = 5 * time.Second
+	DefaultWaitMaxInterval = time.Minute
+	DefaultWaitMultiplier  = 1.5
+)
+
+// ErrWaitFailed is wrapped by the WaitError of a resource that reached a
+// state it cannot recover from, e.g. a FAILED cluster.
+var ErrWaitFailed = errors.New("reached a failed state")
+
+// WaitOptions configures how long and how often Wait polls.
+type WaitOptions struct {
+	// Interval is the wait before the second poll. It grows by Multiplier
+	// after every poll, up to MaxInterval.
+	Interval    time.Duration
+	MaxInterval time.Duration
+	Multiplier  float64
+	// Timeout bounds the whole wait, on top of the deadline of the context.
+	Timeout time.Duration
+	// Progress is called after every poll.
+	Progress func(WaitProgress)
+}
+
+type WaitOption func(*WaitOptions)
+
+func NewWaitOptions(opts ...WaitOption) *WaitOptions {
+	options := &WaitOptions{
+		Interval:    DefaultWaitInterval,
+		MaxInterval: DefaultWaitMaxInterval,
+		Multiplier:  DefaultWaitMultiplier,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+func WithWaitInterval(interval, maxInterval time.Duration) WaitOption {
+	return func(o *WaitOptions) {
+		o.Interval = interval
+		o.MaxInterval = maxInterval
+	}
+}
+
+func WithWaitMultiplier(multiplier float64) WaitOption {
+	return func(o *WaitOptions) {
+		o.Multiplier = multiplier
+	}
+}
+
+func WithWaitTimeout(timeout time.Duration) WaitOption {
+	return func(o *WaitOptions) {
+		o.Timeout = timeout
+	}
+}
+
+func WithWaitProgress(progress func(WaitProgress)) WaitOption {
+	return func(o *WaitOptions) {
+		o.Progress = progress
+	}
+}
+
+// Observation is the state of a resource seen by a single poll.
+type Observation[T any] struct {
+	Value T
+	State string
+	// Detail explains the state, e.g. the stack trace of a failed task.
+	Detail string
+	Done   bool
+	Failed bool
+}
+
+// WaitProgress reports a poll to the Progress callback.
+type WaitProgress struct {
+	Resource string
+	Attempt  int
+	Elapsed  time.Duration
+	State    string
+	Detail   string
+}
+
+// WaitError is returned when a wait ends without the resource reaching the
+// wanted state. It wraps ErrWaitFailed, the error of the context or the error
+// of the last poll, and carries the last observed state.
+type WaitError struct {
+	Resource string
+	Want     string
+	State    string
+	Detail   string
+	// Last is the last observed value, e.g. a *ccloud.ConnectorStatus, nil
+	// when no poll succeeded.
+	Last any
+	Err  error
+}
+
+func (e *WaitError) Error() string {
+	msg := fmt.Sprintf("%s did not become %s", e.Resource, e.Want)
+	if e.State != "" {
+		msg += fmt.Sprintf(" (last state %s)", e.State)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+func (e *WaitError) Unwrap() error {
+	return e.Err
+}
+
+// Wait polls a resource until it is done, fails, the context is done or poll
+// returns an error. resource and want only name the wait in errors and
+// progress reports, e.g. "kafka cluster lkc-1" and "PROVISIONED".
+func Wait[T any](ctx context.Context, resource, want string, poll func(ctx context.Context) (Observation[T], error), opts ...WaitOption) (T, error) {
+	options := NewWaitOptions(opts...)
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	var last *Observation[T]
+	fail := func(err error) (T, error) {
+		waitErr := &WaitError{Resource: resource, Want: want, Err: err}
+		var value T
+		if last != nil {
+			value = last.Value
+			waitErr.State = last.State
+			waitErr.Detail = last.Detail
+			waitErr.Last = last.Value
+		}
+		return value, waitErr
+	}
+
+	start := time.Now()
+	interval := options.Interval
+
+	for attempt := 1; ; attempt++ {
+		observation, err := poll(ctx)
+		if err != nil {
+			return fail(err)
+		}
+		last = &observation
+
+		if options.Progress != nil {
+			options.Progress(WaitProgress{
+				Resource: resource,
+				Attempt:  attempt,
+				Elapsed:  time.Since(start),
+				State:    observation.State,
+				Detail:   observation.Detail,
+			})
+		}
+
+		switch {
+		case observation.Done:
+			return observation.Value, nil
+		case observation.Failed:
+			return fail(ErrWaitFailed)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fail(ctx.Err())
+		case <-timer.C:
+		}
+
+		if options.Multiplier > 1 {
+			interval = time.Duration(float64(interval) * options.Multiplier)
+		}
+		if options.MaxInterval > 0 && interval > options.MaxInterval {
+			interval = options.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWait(t *testing.T) {
+	states := []string{"PROVISIONING", "PROVISIONING", "PROVISIONED"}
+
+	var progress []common.WaitProgress
+	polls := 0
+	value, err := common.Wait(context.Background(), "kafka cluster lkc-1", "PROVISIONED", func(ctx context.Context) (common.Observation[int], error) {
+		state := states[polls]
+		polls++
+		return common.Observation[int]{Value: polls, State: state, Done: state == "PROVISIONED"}, nil
+	},
+		common.WithWaitInterval(time.Millisecond, 2*time.Millisecond),
+		common.WithWaitProgress(func(p common.WaitProgress) { progress = append(progress, p) }),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+	assert.Len(t, progress, 3)
+	assert.Equal(t, 3, progress[2].Attempt)
+	assert.Equal(t, "PROVISIONED", progress[2].State)
+}
+
+func TestWaitFailed(t *testing.T) {
+	value, err := common.Wait(context.Background(), "connector sink", "RUNNING", func(ctx context.Context) (common.Observation[string], error) {
+		return common.Observation[string]{Value: "status", State: "FAILED", Detail: "task 0: boom", Failed: true}, nil
+	})
+
+	assert.Equal(t, "status", value)
+	assert.ErrorIs(t, err, common.ErrWaitFailed)
+	assert.EqualError(t, err, "connector sink did not become RUNNING (last state FAILED): reached a failed state: task 0: boom")
+
+	var waitErr *common.WaitError
+	assert.True(t, errors.As(err, &waitErr))
+	assert.Equal(t, "status", waitErr.Last)
+}
+
+func TestWaitTimeout(t *testing.T) {
+	_, err := common.Wait(context.Background(), "kafka cluster lkc-1", "PROVISIONED", func(ctx context.Context) (common.Observation[int], error) {
+		return common.Observation[int]{State: "PROVISIONING"}, nil
+	},
+		common.WithWaitInterval(time.Millisecond, time.Millisecond),
+		common.WithWaitTimeout(20*time.Millisecond),
+	)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "last state PROVISIONING")
+}
+
+func TestWaitPollError(t *testing.T) {
+	_, err := common.Wait(context.Background(), "kafka cluster lkc-1", "PROVISIONED", func(ctx context.Context) (common.Observation[int], error) {
+		return common.Observation[int]{}, common.ErrNotFound
+	})
+
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
@@ -1,12 +1,15 @@
 package ccloud
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"reflect"
 	"strings"
 
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
 
@@ -46,6 +49,11 @@ type S3SinkConnectorConfig struct {
 	AdditionalProperties  map[string]interface{} `json:"-"`
 }
 
+// LogValue keeps kafka.api.secret out of structured logs.
+func (c S3SinkConnectorConfig) LogValue() slog.Value {
+	return client.RedactedLogValue(c)
+}
+
 type TransformsConfig struct {
 	Name           string
 	Type           string
@@ -87,6 +95,10 @@ type ConnectorInfo struct {
 	Type   string                 `json:"type"`
 }
 
+func (i ConnectorInfo) LogValue() slog.Value {
+	return client.RedactedLogValue(i)
+}
+
 type ConnectorWithExpansions struct {
 	Id     ConnectorId     `json:"id"`
 	Info   ConnectorInfo   `json:"info"`
@@ -151,9 +163,20 @@ func applyDefaults(configMap map[string]interface{}, config interface{}) {
 	}
 }
 
+func (c *ConfluentClient) connectors(environmentId, clusterId string) common.Resource[Connector, []string] {
+	return common.Resource[Connector, []string]{
+		Send: c.send,
+		Api:  "connect",
+		Kind: "Connector",
+		Path: fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors", environmentId, clusterId),
+	}
+}
+
 func (c *ConfluentClient) CreateConnector(environmentId, clusterId, name string, config interface{}) (*Connector, error) {
-	urlPath := fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors", environmentId, clusterId)
+	return c.CreateConnectorWithContext(context.Background(), environmentId, clusterId, name, config)
+}
 
+func (c *ConfluentClient) CreateConnectorWithContext(ctx context.Context, environmentId, clusterId, name string, config interface{}) (*Connector, error) {
 	configBytes, err := json.Marshal(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal config: %w", err)
@@ -172,55 +195,22 @@ func (c *ConfluentClient) CreateConnector(environmentId, clusterId, name string,
 		"config": configMap,
 	}
 
-	req, err := c.doRequest(urlPath, http.MethodPost, payload, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusCreated != req.StatusCode && http.StatusOK != req.StatusCode {
-		defer req.Body.Close()
-
-		var errorBody map[string]interface{}
-		if err := json.NewDecoder(req.Body).Decode(&errorBody); err == nil {
-			errorMsg, _ := json.Marshal(errorBody)
-			return nil, fmt.Errorf("failed to create connector: %s - Response: %s", req.Status, string(errorMsg))
-		}
-		return nil, fmt.Errorf("failed to create connector: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var result Connector
-	err = json.NewDecoder(req.Body).Decode(&result)
-	if err != nil {
-		return nil, err
-	}
-
-	return &result, nil
+	return c.connectors(environmentId, clusterId).Create(ctx, payload, nil)
 }
 
 func (c *ConfluentClient) ListConnectors(environmentId, clusterId string) ([]Connector, error) {
-	urlPath := fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors", environmentId, clusterId)
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list connectors: %s", req.Status)
-	}
-
-	defer req.Body.Close()
+	return c.ListConnectorsWithContext(context.Background(), environmentId, clusterId)
+}
 
-	var names []string
-	err = json.NewDecoder(req.Body).Decode(&names)
+func (c *ConfluentClient) ListConnectorsWithContext(ctx context.Context, environmentId, clusterId string) ([]Connector, error) {
+	names, err := c.connectors(environmentId, clusterId).List(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var connectors []Connector
-	for _, name := range names {
-		conn, err := c.GetConnector(environmentId, clusterId, name)
+	for _, name := range *names {
+		conn, err := c.GetConnectorWithContext(ctx, environmentId, clusterId, name)
 		if err != nil {
 			return nil, err
 		}
@@ -233,108 +223,82 @@ func (c *ConfluentClient) ListConnectors(environmentId, clusterId string) ([]Con
 }
 
 func (c *ConfluentClient) GetConnector(environmentId, clusterId, connectorName string) (*Connector, error) {
-	urlPath := fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors/%s", environmentId, clusterId, connectorName)
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get connector: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var connector Connector
-	err = json.NewDecoder(req.Body).Decode(&connector)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetConnectorWithContext(context.Background(), environmentId, clusterId, connectorName)
+}
 
-	return &connector, nil
+func (c *ConfluentClient) GetConnectorWithContext(ctx context.Context, environmentId, clusterId, connectorName string) (*Connector, error) {
+	return c.connectors(environmentId, clusterId).Get(ctx, connectorName, nil)
 }
 
 func (c *ConfluentClient) GetConnectorStatus(environmentId, clusterId, connectorName string) (*ConnectorStatus, error) {
-	urlPath := fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors/%s/status", environmentId, clusterId, connectorName)
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get connector status: %s", req.Status)
-	}
-
-	defer req.Body.Close()
+	return c.GetConnectorStatusWithContext(context.Background(), environmentId, clusterId, connectorName)
+}
 
-	var status ConnectorStatus
-	err = json.NewDecoder(req.Body).Decode(&status)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClient) GetConnectorStatusWithContext(ctx context.Context, environmentId, clusterId, connectorName string) (*ConnectorStatus, error) {
+	connectors := c.connectors(environmentId, clusterId)
 
-	return &status, nil
+	return common.Do[ConnectorStatus](ctx, c.send, &common.Request{
+		Operation: "connect.GetConnectorStatus",
+		Method:    http.MethodGet,
+		Path:      connectors.ItemPath(connectorName) + "/status",
+	})
 }
 
 func (c *ConfluentClient) DeleteConnector(environmentId, clusterId, connectorName string) error {
-	urlPath := fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors/%s", environmentId, clusterId, connectorName)
-	req, err := c.doRequest(urlPath, http.MethodDelete, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusOK != req.StatusCode && http.StatusNoContent != req.StatusCode && http.StatusAccepted != req.StatusCode {
-		return fmt.Errorf("failed to delete connector: %s", req.Status)
-	}
+	return c.DeleteConnectorWithContext(context.Background(), environmentId, clusterId, connectorName)
+}
 
-	return nil
+func (c *ConfluentClient) DeleteConnectorWithContext(ctx context.Context, environmentId, clusterId, connectorName string) error {
+	return c.connectors(environmentId, clusterId).Delete(ctx, connectorName, nil)
 }
 
 func (c *ConfluentClient) PauseConnector(environmentId, clusterId, connectorName string) error {
-	urlPath := fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors/%s/pause", environmentId, clusterId, connectorName)
-	req, err := c.doRequest(urlPath, http.MethodPut, nil, nil)
-	if err != nil {
-		return err
-	}
+	return c.PauseConnectorWithContext(context.Background(), environmentId, clusterId, connectorName)
+}
 
-	if http.StatusOK != req.StatusCode && http.StatusAccepted != req.StatusCode {
-		return fmt.Errorf("failed to pause connector: %s", req.Status)
-	}
+func (c *ConfluentClient) PauseConnectorWithContext(ctx context.Context, environmentId, clusterId, connectorName string) error {
+	connectors := c.connectors(environmentId, clusterId)
 
-	return nil
+	return common.Exec(ctx, c.send, &common.Request{
+		Operation: "connect.PauseConnector",
+		Method:    http.MethodPut,
+		Path:      connectors.ItemPath(connectorName) + "/pause",
+	})
 }
 
 func (c *ConfluentClient) ResumeConnector(environmentId, clusterId, connectorName string) error {
-	urlPath := fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors/%s/resume", environmentId, clusterId, connectorName)
-	req, err := c.doRequest(urlPath, http.MethodPut, nil, nil)
-	if err != nil {
-		return err
-	}
+	return c.ResumeConnectorWithContext(context.Background(), environmentId, clusterId, connectorName)
+}
 
-	if http.StatusOK != req.StatusCode && http.StatusAccepted != req.StatusCode {
-		return fmt.Errorf("failed to resume connector: %s", req.Status)
-	}
+func (c *ConfluentClient) ResumeConnectorWithContext(ctx context.Context, environmentId, clusterId, connectorName string) error {
+	connectors := c.connectors(environmentId, clusterId)
 
-	return nil
+	return common.Exec(ctx, c.send, &common.Request{
+		Operation: "connect.ResumeConnector",
+		Method:    http.MethodPut,
+		Path:      connectors.ItemPath(connectorName) + "/resume",
+	})
 }
 
 func (c *ConfluentClient) RestartConnector(environmentId, clusterId, connectorName string) error {
-	urlPath := fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors/%s/restart", environmentId, clusterId, connectorName)
-	req, err := c.doRequest(urlPath, http.MethodPost, nil, nil)
-	if err != nil {
-		return err
-	}
+	return c.RestartConnectorWithContext(context.Background(), environmentId, clusterId, connectorName)
+}
 
-	if http.StatusOK != req.StatusCode && http.StatusAccepted != req.StatusCode {
-		return fmt.Errorf("failed to restart connector: %s", req.Status)
-	}
+func (c *ConfluentClient) RestartConnectorWithContext(ctx context.Context, environmentId, clusterId, connectorName string) error {
+	connectors := c.connectors(environmentId, clusterId)
 
-	return nil
+	return common.Exec(ctx, c.send, &common.Request{
+		Operation: "connect.RestartConnector",
+		Method:    http.MethodPost,
+		Path:      connectors.ItemPath(connectorName) + "/restart",
+	})
 }
 
 func (c *ConfluentClient) UpdateConnectorConfig(environmentId, clusterId, connectorName string, newConfig interface{}) (*Connector, error) {
-	urlPath := fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors/%s/config", environmentId, clusterId, connectorName)
+	return c.UpdateConnectorConfigWithContext(context.Background(), environmentId, clusterId, connectorName, newConfig)
+}
 
+func (c *ConfluentClient) UpdateConnectorConfigWithContext(ctx context.Context, environmentId, clusterId, connectorName string, newConfig interface{}) (*Connector, error) {
 	configBytes, err := json.Marshal(newConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal config: %w", err)
@@ -348,63 +312,42 @@ func (c *ConfluentClient) UpdateConnectorConfig(environmentId, clusterId, connec
 	applyDefaults(configMap, newConfig)
 	configMap["name"] = connectorName
 
-	req, err := c.doRequest(urlPath, http.MethodPut, configMap, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode && http.StatusCreated != req.StatusCode {
-		defer req.Body.Close()
-
-		var errorBody map[string]interface{}
-		if err := json.NewDecoder(req.Body).Decode(&errorBody); err == nil {
-			errorMsg, _ := json.Marshal(errorBody)
-			return nil, fmt.Errorf("failed to update connector config: %s - Response: %s", req.Status, string(errorMsg))
-		}
-		return nil, fmt.Errorf("failed to update connector config: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var connector Connector
-	err = json.NewDecoder(req.Body).Decode(&connector)
-	if err != nil {
-		return nil, err
-	}
+	connectors := c.connectors(environmentId, clusterId)
 
-	return &connector, nil
+	return common.Do[Connector](ctx, c.send, &common.Request{
+		Operation: "connect.UpdateConnectorConfig",
+		Method:    http.MethodPut,
+		Path:      connectors.ItemPath(connectorName) + "/config",
+		Body:      configMap,
+	})
 }
 
 func (c *ConfluentClient) ListConnectorsWithExpansions(environmentId, clusterId string, expand ...string) (map[string]ConnectorWithExpansions, error) {
-	urlPath := fmt.Sprintf("/connect/v1/environments/%s/clusters/%s/connectors", environmentId, clusterId)
-
-	expandStr := strings.Join(expand, ",")
-
-	params := listConnectorsParams{Expand: expandStr}
-
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, params)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		defer req.Body.Close()
-		return nil, fmt.Errorf("failed to list connectors with expansions: %s", req.Status)
-	}
+	return c.ListConnectorsWithExpansionsWithContext(context.Background(), environmentId, clusterId, expand...)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClient) ListConnectorsWithExpansionsWithContext(ctx context.Context, environmentId, clusterId string, expand ...string) (map[string]ConnectorWithExpansions, error) {
+	params := listConnectorsParams{Expand: strings.Join(expand, ",")}
 
-	var result map[string]ConnectorWithExpansions
-	err = json.NewDecoder(req.Body).Decode(&result)
+	result, err := common.Do[map[string]ConnectorWithExpansions](ctx, c.send, &common.Request{
+		Operation: "connect.ListConnectorsWithExpansions",
+		Method:    http.MethodGet,
+		Path:      c.connectors(environmentId, clusterId).Path,
+		Params:    params,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	return *result, nil
 }
 
 func (c *ConfluentClient) GetConnectorWithExpansions(environmentId, clusterId, connectorName string, expand ...string) (*ConnectorWithExpansions, error) {
-	connectors, err := c.ListConnectorsWithExpansions(environmentId, clusterId, expand...)
+	return c.GetConnectorWithExpansionsWithContext(context.Background(), environmentId, clusterId, connectorName, expand...)
+}
+
+func (c *ConfluentClient) GetConnectorWithExpansionsWithContext(ctx context.Context, environmentId, clusterId, connectorName string, expand ...string) (*ConnectorWithExpansions, error) {
+	connectors, err := c.ListConnectorsWithExpansionsWithContext(ctx, environmentId, clusterId, expand...)
 	if err != nil {
 		return nil, err
 	}
@@ -413,5 +356,5 @@ func (c *ConfluentClient) GetConnectorWithExpansions(environmentId, clusterId, c
 		return &connector, nil
 	}
 
-	return nil, fmt.Errorf("connector '%s' not found", connectorName)
+	return nil, fmt.Errorf("connector '%s': %w", connectorName, common.ErrNotFound)
 }
@@ -0,0 +1,171 @@
+// Package credentials resolves the API keys used to reach Confluent Cloud,
+// a Kafka cluster (lkc-) or a Schema Registry (lsrc-) from a chain of
+// providers.
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+)
+
+// Cloud is the resource id of the Confluent Cloud API key.
+const Cloud = ""
+
+var ErrNotFound = errors.New("credentials not found")
+
+type Credential struct {
+	ApiKey    string
+	ApiSecret string
+	// Source describes where the credential was found, e.g. "env".
+	Source string
+}
+
+func (c Credential) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("api_key", c.ApiKey),
+		slog.String("api_secret", client.Redacted),
+		slog.String("source", c.Source),
+	)
+}
+
+func (c *Credential) Auth() client.ClientAuth {
+	return client.NewBasicAuth(c.ApiKey, c.ApiSecret)
+}
+
+// Provider looks up the credential of a resource. Providers that do not know
+// the resource return an error matching ErrNotFound.
+type Provider interface {
+	Retrieve(resourceId string) (*Credential, error)
+}
+
+// Chain asks each provider in turn and returns the first credential found.
+// A key of the resource itself is preferred over a key shared by every
+// cluster or Schema Registry, whichever provider holds them: the shared keys
+// of the built-in providers are only looked up once no provider has a key of
+// the resource.
+type Chain []Provider
+
+func NewChain(providers ...Provider) Chain {
+	return Chain(providers)
+}
+
+// Default returns the chain used when no explicit credential is given:
+// environment variables, the .env file of the working directory and the
+// profile file.
+func Default() Chain {
+	return NewChain(NewEnvProvider(), NewDotEnvProvider(), NewProfileProvider())
+}
+
+func (c Chain) Retrieve(resourceId string) (*Credential, error) {
+	return retrieveAny(c, resourceId)
+}
+
+func (c Chain) retrieve(resourceId string, shared bool) (*Credential, error) {
+	for _, provider := range c {
+		credential, err := retrieveFrom(provider, resourceId, shared)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return credential, nil
+	}
+
+	return nil, notFound(resourceId)
+}
+
+// sharedKeyProvider is implemented by providers that fall back to a key
+// shared by every resource of a kind. retrieve looks up either the key of
+// the resource itself or the shared one.
+type sharedKeyProvider interface {
+	retrieve(resourceId string, shared bool) (*Credential, error)
+}
+
+// retrieveFrom asks provider for the key of the resource itself or for the
+// shared one. Providers that cannot tell them apart are only asked once.
+func retrieveFrom(provider Provider, resourceId string, shared bool) (*Credential, error) {
+	if p, ok := provider.(sharedKeyProvider); ok {
+		return p.retrieve(resourceId, shared)
+	}
+	if shared {
+		return nil, notFound(resourceId)
+	}
+	return provider.Retrieve(resourceId)
+}
+
+// retrieveAny prefers the key of the resource itself over the shared one.
+func retrieveAny(p sharedKeyProvider, resourceId string) (*Credential, error) {
+	credential, err := p.retrieve(resourceId, false)
+	if errors.Is(err, ErrNotFound) {
+		return p.retrieve(resourceId, true)
+	}
+	return credential, err
+}
+
+// Auth resolves the credential of resourceId into a client.ClientAuth.
+func Auth(provider Provider, resourceId string) (client.ClientAuth, error) {
+	credential, err := provider.Retrieve(resourceId)
+	if err != nil {
+		return nil, err
+	}
+	return credential.Auth(), nil
+}
+
+// StaticProvider holds credentials given explicitly by the caller.
+type StaticProvider struct {
+	credentials map[string]Credential
+}
+
+func NewStaticProvider() *StaticProvider {
+	return &StaticProvider{credentials: map[string]Credential{}}
+}
+
+func (p *StaticProvider) WithCloud(apiKey, apiSecret string) *StaticProvider {
+	return p.WithResource(Cloud, apiKey, apiSecret)
+}
+
+func (p *StaticProvider) WithResource(resourceId, apiKey, apiSecret string) *StaticProvider {
+	p.credentials[resourceId] = Credential{ApiKey: apiKey, ApiSecret: apiSecret, Source: "static"}
+	return p
+}
+
+func (p *StaticProvider) Retrieve(resourceId string) (*Credential, error) {
+	credential, ok := p.credentials[resourceId]
+	if !ok {
+		return nil, notFound(resourceId)
+	}
+	return &credential, nil
+}
+
+type resourceKind int
+
+const (
+	kindCloud resourceKind = iota
+	kindCluster
+	kindSchemaRegistry
+	kindOther
+)
+
+func kindOf(resourceId string) resourceKind {
+	switch {
+	case resourceId == Cloud:
+		return kindCloud
+	case strings.HasPrefix(resourceId, "lkc-"):
+		return kindCluster
+	case strings.HasPrefix(resourceId, "lsrc-"):
+		return kindSchemaRegistry
+	}
+	return kindOther
+}
+
+func notFound(resourceId string) error {
+	if resourceId == Cloud {
+		return fmt.Errorf("%w for the cloud API", ErrNotFound)
+	}
+	return fmt.Errorf("%w for %s", ErrNotFound, resourceId)
+}
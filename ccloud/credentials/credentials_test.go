@@ -0,0 +1,169 @@
+package credentials_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvProvider(t *testing.T) {
+	t.Setenv("CONFLUENT_API_KEY", "cloud-key")
+	t.Setenv("CONFLUENT_API_SECRET", "cloud-secret")
+	t.Setenv("CONFLUENT_LKC_ABC123_API_KEY", "lkc-key")
+	t.Setenv("CONFLUENT_LKC_ABC123_API_SECRET", "lkc-secret")
+	t.Setenv("CONFLUENT_CLUSTER_API_KEY", "any-cluster-key")
+	t.Setenv("CONFLUENT_CLUSTER_API_SECRET", "any-cluster-secret")
+
+	provider := credentials.NewEnvProvider()
+
+	tests := []struct {
+		resourceId string
+		apiKey     string
+	}{
+		{credentials.Cloud, "cloud-key"},
+		{"lkc-abc123", "lkc-key"},
+		{"lkc-other", "any-cluster-key"},
+	}
+
+	for _, tt := range tests {
+		credential, err := provider.Retrieve(tt.resourceId)
+		assert.NoError(t, err, tt.resourceId)
+		assert.Equal(t, tt.apiKey, credential.ApiKey, tt.resourceId)
+		assert.Equal(t, "env", credential.Source)
+	}
+
+	_, err := provider.Retrieve("lsrc-xyz789")
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
+
+func TestEnvProviderIncompleteKey(t *testing.T) {
+	t.Setenv("CONFLUENT_SCHEMA_REGISTRY_API_KEY", "sr-key")
+
+	_, err := credentials.NewEnvProvider().Retrieve("lsrc-xyz789")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, credentials.ErrNotFound)
+}
+
+func TestDotEnvProvider(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, ".env")
+	err := os.WriteFile(filename, []byte("CONFLUENT_LSRC_XYZ789_API_KEY=sr-key\nCONFLUENT_LSRC_XYZ789_API_SECRET=sr-secret\n"), 0o600)
+	assert.NoError(t, err)
+
+	provider := credentials.NewDotEnvProvider(filepath.Join(dir, "missing.env"), filename)
+
+	credential, err := provider.Retrieve("lsrc-xyz789")
+	assert.NoError(t, err)
+	assert.Equal(t, "sr-key", credential.ApiKey)
+	assert.Equal(t, "sr-secret", credential.ApiSecret)
+	assert.Equal(t, "dotenv:"+filename, credential.Source)
+
+	_, err = provider.Retrieve(credentials.Cloud)
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
+
+func TestProfileProvider(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "credentials.json")
+	err := os.WriteFile(filename, []byte(`{
+		"profiles": {
+			"default": {"cloud": {"api_key": "default-key", "api_secret": "default-secret"}},
+			"prod": {
+				"cloud": {"api_key": "prod-key", "api_secret": "prod-secret"},
+				"schema_registry": {"api_key": "sr-key", "api_secret": "sr-secret"},
+				"resources": {"lkc-abc123": {"api_key": "lkc-key", "api_secret": "lkc-secret"}}
+			}
+		}
+	}`), 0o600)
+	assert.NoError(t, err)
+
+	t.Setenv(credentials.ProfileFileEnv, filename)
+	t.Setenv(credentials.ProfileEnv, "prod")
+
+	provider := credentials.NewProfileProvider()
+
+	credential, err := provider.Retrieve(credentials.Cloud)
+	assert.NoError(t, err)
+	assert.Equal(t, "prod-key", credential.ApiKey)
+
+	credential, err = provider.Retrieve("lkc-abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, "lkc-key", credential.ApiKey)
+
+	credential, err = provider.Retrieve("lsrc-any")
+	assert.NoError(t, err)
+	assert.Equal(t, "sr-key", credential.ApiKey)
+
+	_, err = provider.Retrieve("lkc-other")
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
+
+func TestChain(t *testing.T) {
+	t.Setenv("CONFLUENT_API_KEY", "env-key")
+	t.Setenv("CONFLUENT_API_SECRET", "env-secret")
+
+	chain := credentials.NewChain(
+		credentials.NewStaticProvider().WithResource("lkc-abc123", "static-key", "static-secret"),
+		credentials.NewEnvProvider(),
+	)
+
+	credential, err := chain.Retrieve(credentials.Cloud)
+	assert.NoError(t, err)
+	assert.Equal(t, "env-key", credential.ApiKey)
+
+	auth, err := credentials.Auth(chain, "lkc-abc123")
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://pkc-xyz.confluent.cloud", nil)
+	assert.NoError(t, auth.SetAuth(req))
+	user, pass, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "static-key", user)
+	assert.Equal(t, "static-secret", pass)
+
+	_, err = chain.Retrieve("lsrc-xyz789")
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
+
+func TestChainPrefersResourceKeys(t *testing.T) {
+	dir := t.TempDir()
+	dotEnv := filepath.Join(dir, ".env")
+	err := os.WriteFile(dotEnv, []byte("CONFLUENT_LKC_DOTENV_API_KEY=dotenv-key\nCONFLUENT_LKC_DOTENV_API_SECRET=dotenv-secret\n"), 0o600)
+	assert.NoError(t, err)
+
+	profile := filepath.Join(dir, "credentials.json")
+	err = os.WriteFile(profile, []byte(`{
+		"profiles": {
+			"default": {
+				"cluster": {"api_key": "profile-cluster-key", "api_secret": "profile-cluster-secret"},
+				"resources": {"lkc-profile": {"api_key": "profile-key", "api_secret": "profile-secret"}}
+			}
+		}
+	}`), 0o600)
+	assert.NoError(t, err)
+
+	t.Setenv("CONFLUENT_CLUSTER_API_KEY", "env-cluster-key")
+	t.Setenv("CONFLUENT_CLUSTER_API_SECRET", "env-cluster-secret")
+
+	// Nested chains are resolved the same way.
+	chain := credentials.NewChain(
+		credentials.NewEnvProvider(),
+		credentials.NewChain(
+			credentials.NewDotEnvProvider(dotEnv),
+			&credentials.ProfileProvider{Filename: profile, Profile: credentials.DefaultProfile},
+		),
+	)
+
+	for resourceId, apiKey := range map[string]string{
+		"lkc-profile": "profile-key",
+		"lkc-dotenv":  "dotenv-key",
+		"lkc-other":   "env-cluster-key",
+	} {
+		credential, err := chain.Retrieve(resourceId)
+		assert.NoError(t, err)
+		assert.Equal(t, apiKey, credential.ApiKey, resourceId)
+	}
+}
@@ -0,0 +1,124 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	envPrefix               = "CONFLUENT"
+	envClusterPrefix        = "CONFLUENT_CLUSTER"
+	envSchemaRegistryPrefix = "CONFLUENT_SCHEMA_REGISTRY"
+)
+
+// EnvProvider reads credentials from variables named after the resource:
+//
+//	CONFLUENT_API_KEY / CONFLUENT_API_SECRET                       cloud API
+//	CONFLUENT_LKC_ABC123_API_KEY / CONFLUENT_LKC_ABC123_API_SECRET lkc-abc123
+//	CONFLUENT_CLUSTER_API_KEY / CONFLUENT_CLUSTER_API_SECRET       any lkc-
+//	CONFLUENT_SCHEMA_REGISTRY_API_KEY / ..._API_SECRET             any lsrc-
+//
+// A resource specific pair takes precedence over the generic one.
+type EnvProvider struct {
+	lookup func(string) (string, bool)
+	source string
+}
+
+func NewEnvProvider() *EnvProvider {
+	return &EnvProvider{lookup: os.LookupEnv, source: "env"}
+}
+
+func (p *EnvProvider) Retrieve(resourceId string) (*Credential, error) {
+	return retrieveAny(p, resourceId)
+}
+
+func (p *EnvProvider) retrieve(resourceId string, shared bool) (*Credential, error) {
+	prefix := envPrefixFor(resourceId, shared)
+	if prefix == "" {
+		return nil, notFound(resourceId)
+	}
+
+	keyName, secretName := prefix+"_API_KEY", prefix+"_API_SECRET"
+	key, hasKey := p.lookup(keyName)
+	secret, hasSecret := p.lookup(secretName)
+
+	if !hasKey && !hasSecret {
+		return nil, notFound(resourceId)
+	}
+	if key == "" || secret == "" {
+		return nil, fmt.Errorf("%s: both %s and %s must be set", p.source, keyName, secretName)
+	}
+
+	return &Credential{ApiKey: key, ApiSecret: secret, Source: p.source}, nil
+}
+
+// envPrefixFor returns the prefix of the variables holding the key of the
+// resource itself, or of the key shared by its kind. It is empty when the
+// resource has no such key.
+func envPrefixFor(resourceId string, shared bool) string {
+	kind := kindOf(resourceId)
+	if !shared {
+		if kind == kindCloud {
+			return envPrefix
+		}
+		return envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(resourceId, "-", "_"))
+	}
+
+	switch kind {
+	case kindCluster:
+		return envClusterPrefix
+	case kindSchemaRegistry:
+		return envSchemaRegistryPrefix
+	}
+	return ""
+}
+
+// NewDotEnvProvider reads the same variables as EnvProvider from .env files,
+// ".env" when none is given. Missing files are ignored.
+func NewDotEnvProvider(filenames ...string) *DotEnvProvider {
+	if len(filenames) == 0 {
+		filenames = []string{".env"}
+	}
+	return &DotEnvProvider{filenames: filenames}
+}
+
+type DotEnvProvider struct {
+	filenames []string
+}
+
+func (p *DotEnvProvider) Retrieve(resourceId string) (*Credential, error) {
+	return retrieveAny(p, resourceId)
+}
+
+func (p *DotEnvProvider) retrieve(resourceId string, shared bool) (*Credential, error) {
+	for _, filename := range p.filenames {
+		values, err := godotenv.Read(filename)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+
+		env := &EnvProvider{
+			lookup: func(key string) (string, bool) {
+				value, ok := values[key]
+				return value, ok
+			},
+			source: "dotenv:" + filename,
+		}
+
+		credential, err := env.retrieve(resourceId, shared)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		return credential, err
+	}
+
+	return nil, notFound(resourceId)
+}
@@ -0,0 +1,139 @@
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	DefaultProfile = "default"
+
+	// ProfileFileEnv and ProfileEnv override the profile file location and
+	// the profile used by NewProfileProvider.
+	ProfileFileEnv = "CONFLUENT_CREDENTIALS_FILE"
+	ProfileEnv     = "CONFLUENT_PROFILE"
+)
+
+// ProfileFile is the JSON document read by ProfileProvider:
+//
+//	{
+//	  "profiles": {
+//	    "default": {
+//	      "cloud": {"api_key": "...", "api_secret": "..."},
+//	      "cluster": {"api_key": "...", "api_secret": "..."},
+//	      "resources": {
+//	        "lkc-abc123": {"api_key": "...", "api_secret": "..."},
+//	        "lsrc-xyz789": {"api_key": "...", "api_secret": "..."}
+//	      }
+//	    }
+//	  }
+//	}
+//
+// "cluster" and "schema_registry" are used for any lkc- and lsrc- resource
+// without an entry of its own.
+type ProfileFile struct {
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+type Profile struct {
+	Cloud          *ProfileKey           `json:"cloud,omitempty"`
+	Cluster        *ProfileKey           `json:"cluster,omitempty"`
+	SchemaRegistry *ProfileKey           `json:"schema_registry,omitempty"`
+	Resources      map[string]ProfileKey `json:"resources,omitempty"`
+}
+
+type ProfileKey struct {
+	ApiKey    string `json:"api_key"`
+	ApiSecret string `json:"api_secret"`
+}
+
+type ProfileProvider struct {
+	Filename string
+	Profile  string
+}
+
+// NewProfileProvider reads the profile named by CONFLUENT_PROFILE, or
+// "default", from CONFLUENT_CREDENTIALS_FILE or ~/.confluent/credentials.json.
+func NewProfileProvider() *ProfileProvider {
+	provider := &ProfileProvider{
+		Filename: os.Getenv(ProfileFileEnv),
+		Profile:  os.Getenv(ProfileEnv),
+	}
+
+	if provider.Filename == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			provider.Filename = filepath.Join(home, ".confluent", "credentials.json")
+		}
+	}
+	if provider.Profile == "" {
+		provider.Profile = DefaultProfile
+	}
+
+	return provider
+}
+
+func (p *ProfileProvider) Retrieve(resourceId string) (*Credential, error) {
+	return retrieveAny(p, resourceId)
+}
+
+func (p *ProfileProvider) retrieve(resourceId string, shared bool) (*Credential, error) {
+	if p.Filename == "" {
+		return nil, notFound(resourceId)
+	}
+
+	data, err := os.ReadFile(p.Filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, notFound(resourceId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", p.Filename, err)
+	}
+
+	var file ProfileFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", p.Filename, err)
+	}
+
+	profile, ok := file.Profiles[p.Profile]
+	if !ok {
+		return nil, notFound(resourceId)
+	}
+
+	key := profile.lookup(resourceId, shared)
+	if key == nil {
+		return nil, notFound(resourceId)
+	}
+	if key.ApiKey == "" || key.ApiSecret == "" {
+		return nil, fmt.Errorf("%s: profile %q has an incomplete key for %q", p.Filename, p.Profile, resourceId)
+	}
+
+	return &Credential{
+		ApiKey:    key.ApiKey,
+		ApiSecret: key.ApiSecret,
+		Source:    fmt.Sprintf("profile:%s[%s]", p.Filename, p.Profile),
+	}, nil
+}
+
+func (p *Profile) lookup(resourceId string, shared bool) *ProfileKey {
+	if !shared {
+		if key, ok := p.Resources[resourceId]; ok {
+			return &key
+		}
+		if kindOf(resourceId) == kindCloud {
+			return p.Cloud
+		}
+		return nil
+	}
+
+	switch kindOf(resourceId) {
+	case kindCluster:
+		return p.Cluster
+	case kindSchemaRegistry:
+		return p.SchemaRegistry
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+// Package crn builds, parses and matches Confluent Resource Names, the
+// crn://confluent.cloud/organization=.../environment=... strings that role
+// bindings are scoped by.
+package crn
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	Scheme           = "crn://"
+	DefaultAuthority = "confluent.cloud"
+
+	// Wildcard is the id matching every resource of a type. An id ending with
+	// it, e.g. "orders*", matches the resources whose id has that prefix.
+	Wildcard = "*"
+)
+
+var ErrInvalid = errors.New("invalid crn")
+
+type ResourceType string
+
+const (
+	TypeOrganization     ResourceType = "organization"
+	TypeEnvironment      ResourceType = "environment"
+	TypeCloudCluster     ResourceType = "cloud-cluster"
+	TypeKafka            ResourceType = "kafka"
+	TypeTopic            ResourceType = "topic"
+	TypeGroup            ResourceType = "group"
+	TypeTransactionalId  ResourceType = "transactional-id"
+	TypeConnector        ResourceType = "connector"
+	TypeKsql             ResourceType = "ksql"
+	TypeSchemaRegistry   ResourceType = "schema-registry"
+	TypeSubject          ResourceType = "subject"
+	TypeFlinkRegion      ResourceType = "flink-region"
+	TypeComputePool      ResourceType = "compute-pool"
+	TypeServiceAccount   ResourceType = "service-account"
+	TypeUser             ResourceType = "user"
+	TypeIdentityProvider ResourceType = "identity-provider"
+	TypeIdentityPool     ResourceType = "identity-pool"
+	TypeGroupMapping     ResourceType = "group-mapping"
+)
+
+// parents lists the types each type can be nested in. Organizations are the
+// root of every CRN.
+var parents = map[ResourceType][]ResourceType{
+	TypeOrganization:     nil,
+	TypeEnvironment:      {TypeOrganization},
+	TypeCloudCluster:     {TypeEnvironment},
+	TypeKafka:            {TypeCloudCluster},
+	TypeTopic:            {TypeKafka},
+	TypeGroup:            {TypeKafka},
+	TypeTransactionalId:  {TypeKafka},
+	TypeConnector:        {TypeCloudCluster},
+	TypeKsql:             {TypeCloudCluster},
+	TypeSchemaRegistry:   {TypeEnvironment},
+	TypeSubject:          {TypeSchemaRegistry},
+	TypeFlinkRegion:      {TypeEnvironment},
+	TypeComputePool:      {TypeFlinkRegion},
+	TypeServiceAccount:   {TypeOrganization},
+	TypeUser:             {TypeOrganization},
+	TypeIdentityProvider: {TypeOrganization},
+	TypeIdentityPool:     {TypeIdentityProvider},
+	TypeGroupMapping:     {TypeOrganization},
+}
+
+// named lists the types whose ids are names chosen by users, which may contain
+// slashes, e.g. the subject "team/orders-value".
+var named = []ResourceType{TypeTopic, TypeGroup, TypeTransactionalId, TypeConnector, TypeSubject}
+
+// Element is a type=id segment of a CRN.
+type Element struct {
+	Type ResourceType
+	Id   string
+}
+
+func (e Element) String() string {
+	return string(e.Type) + "=" + e.Id
+}
+
+// CRN is a parsed Confluent Resource Name. The zero value is empty; use New
+// and the builder methods, or Parse. Builders never modify their receiver.
+type CRN struct {
+	Authority string
+	Elements  []Element
+}
+
+// New returns the CRN of an organization.
+func New(organizationId string) CRN {
+	return CRN{Authority: DefaultAuthority, Elements: []Element{{TypeOrganization, organizationId}}}
+}
+
+// Parse parses and validates s, see Validate. Names, e.g. of topics or
+// subjects, may contain slashes; a slash only starts a new element when it is
+// followed by a known type and "=".
+func Parse(s string) (CRN, error) {
+	rest, ok := strings.CutPrefix(s, Scheme)
+	if !ok {
+		return CRN{}, fmt.Errorf("%w %q: missing %s", ErrInvalid, s, Scheme)
+	}
+
+	segments := strings.Split(rest, "/")
+	c := CRN{Authority: segments[0]}
+	for _, segment := range segments[1:] {
+		key, id, ok := strings.Cut(segment, "=")
+		if _, known := parents[ResourceType(key)]; !(ok && known) && len(c.Elements) > 0 && slices.Contains(named, c.Last().Type) {
+			// A slash inside a name, the segment belongs to the previous id.
+			c.Elements[len(c.Elements)-1].Id += "/" + segment
+			continue
+		}
+		if !ok {
+			return CRN{}, fmt.Errorf("%w %q: segment %q is not type=id", ErrInvalid, s, segment)
+		}
+		c.Elements = append(c.Elements, Element{ResourceType(key), id})
+	}
+
+	if err := c.Validate(); err != nil {
+		return CRN{}, err
+	}
+
+	return c, nil
+}
+
+// MustParse is Parse for constants, it panics on invalid input.
+func MustParse(s string) CRN {
+	c, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Validate checks that the CRN starts with an organization, that every type
+// is known and nested in one of its parents, that a kafka element repeats the
+// id of its cloud cluster and that wildcards only end the last id.
+func (c CRN) Validate() error {
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalid, c.String(), fmt.Sprintf(format, args...))
+	}
+
+	if c.Authority == "" {
+		return fail("missing authority")
+	}
+	if len(c.Elements) == 0 || c.Elements[0].Type != TypeOrganization {
+		return fail("must start with %s", TypeOrganization)
+	}
+
+	for i, element := range c.Elements {
+		allowed, ok := parents[element.Type]
+		if !ok {
+			return fail("unknown resource type %q", element.Type)
+		}
+		if i > 0 && !slices.Contains(allowed, c.Elements[i-1].Type) {
+			return fail("%s cannot be nested in %s", element.Type, c.Elements[i-1].Type)
+		}
+		if element.Id == "" {
+			return fail("missing id of %s", element.Type)
+		}
+		if star := strings.Index(element.Id, Wildcard); star >= 0 && (i < len(c.Elements)-1 || star < len(element.Id)-1) {
+			return fail("wildcard in %s is only allowed at the end of the last id", element)
+		}
+		if element.Type == TypeKafka && element.Id != c.Elements[i-1].Id {
+			return fail("%s does not match %s", element, c.Elements[i-1])
+		}
+	}
+
+	return nil
+}
+
+func (c CRN) String() string {
+	var b strings.Builder
+	b.WriteString(Scheme)
+	b.WriteString(c.Authority)
+	for _, element := range c.Elements {
+		b.WriteString("/")
+		b.WriteString(element.String())
+	}
+	return b.String()
+}
+
+func (c CRN) IsZero() bool {
+	return c.Authority == "" && len(c.Elements) == 0
+}
+
+// Child returns c with an element appended.
+func (c CRN) Child(t ResourceType, id string) CRN {
+	elements := make([]Element, len(c.Elements), len(c.Elements)+1)
+	copy(elements, c.Elements)
+	return CRN{Authority: c.Authority, Elements: append(elements, Element{t, id})}
+}
+
+// Parent returns c without its last element.
+func (c CRN) Parent() CRN {
+	if len(c.Elements) == 0 {
+		return c
+	}
+	n := len(c.Elements) - 1
+	return CRN{Authority: c.Authority, Elements: c.Elements[:n:n]}
+}
+
+// Last returns the element naming the resource, e.g. the topic of a topic CRN.
+func (c CRN) Last() Element {
+	if len(c.Elements) == 0 {
+		return Element{}
+	}
+	return c.Elements[len(c.Elements)-1]
+}
+
+func (c CRN) Type() ResourceType {
+	return c.Last().Type
+}
+
+// Get returns the id of the element of type t, e.g. the environment id.
+func (c CRN) Get(t ResourceType) (string, bool) {
+	for _, element := range c.Elements {
+		if element.Type == t {
+			return element.Id, true
+		}
+	}
+	return "", false
+}
+
+// IsPattern reports whether the last id is a wildcard or a prefix.
+func (c CRN) IsPattern() bool {
+	return strings.HasSuffix(c.Last().Id, Wildcard)
+}
+
+// Contains reports whether the resources named by other are all named by c:
+// other is c, a resource below c, or matches the wildcard of c.
+func (c CRN) Contains(other CRN) bool {
+	if c.Authority != other.Authority || len(c.Elements) > len(other.Elements) {
+		return false
+	}
+
+	for i, element := range c.Elements {
+		if element.Type != other.Elements[i].Type || !matchId(element.Id, other.Elements[i].Id) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchId(pattern, id string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, Wildcard); ok {
+		return strings.HasPrefix(id, prefix)
+	}
+	return pattern == id
+}
+
+func (c CRN) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *CRN) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// Prefix returns the id pattern matching the ids starting with prefix.
+func Prefix(prefix string) string {
+	return prefix + Wildcard
+}
+
+func (c CRN) Environment(id string) CRN {
+	return c.Child(TypeEnvironment, id)
+}
+
+// KafkaCluster appends the cloud-cluster and kafka elements of a cluster,
+// the scope of its topics, groups and transactional ids.
+func (c CRN) KafkaCluster(id string) CRN {
+	return c.Child(TypeCloudCluster, id).Child(TypeKafka, id)
+}
+
+// CloudCluster appends only the cloud-cluster element, the scope of
+// connectors and ksqlDB clusters.
+func (c CRN) CloudCluster(id string) CRN {
+	return c.Child(TypeCloudCluster, id)
+}
+
+func (c CRN) Topic(name string) CRN {
+	return c.Child(TypeTopic, name)
+}
+
+func (c CRN) Group(name string) CRN {
+	return c.Child(TypeGroup, name)
+}
+
+func (c CRN) TransactionalId(id string) CRN {
+	return c.Child(TypeTransactionalId, id)
+}
+
+func (c CRN) Connector(name string) CRN {
+	return c.Child(TypeConnector, name)
+}
+
+func (c CRN) Ksql(id string) CRN {
+	return c.Child(TypeKsql, id)
+}
+
+func (c CRN) SchemaRegistry(id string) CRN {
+	return c.Child(TypeSchemaRegistry, id)
+}
+
+func (c CRN) Subject(name string) CRN {
+	return c.Child(TypeSubject, name)
+}
+
+// FlinkRegion appends a region, e.g. "aws.us-east-1".
+func (c CRN) FlinkRegion(region string) CRN {
+	return c.Child(TypeFlinkRegion, region)
+}
+
+func (c CRN) ComputePool(id string) CRN {
+	return c.Child(TypeComputePool, id)
+}
+
+func (c CRN) ServiceAccount(id string) CRN {
+	return c.Child(TypeServiceAccount, id)
+}
+
+func (c CRN) User(id string) CRN {
+	return c.Child(TypeUser, id)
+}
+
+func (c CRN) IdentityProvider(id string) CRN {
+	return c.Child(TypeIdentityProvider, id)
+}
+
+func (c CRN) IdentityPool(id string) CRN {
+	return c.Child(TypeIdentityPool, id)
+}
+
+func (c CRN) GroupMapping(id string) CRN {
+	return c.Child(TypeGroupMapping, id)
+}
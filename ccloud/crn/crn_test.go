@@ -0,0 +1,136 @@
+package crn_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const org = "1111aaaa-11aa-11aa-11aa-111111aaaaaa"
+
+func TestBuilders(t *testing.T) {
+	env := crn.New(org).Environment("env-1")
+	cluster := env.KafkaCluster("lkc-1")
+
+	tests := []struct {
+		crn  crn.CRN
+		want string
+	}{
+		{env, "crn://confluent.cloud/organization=" + org + "/environment=env-1"},
+		{cluster.Topic(crn.Prefix("orders")), "crn://confluent.cloud/organization=" + org + "/environment=env-1/cloud-cluster=lkc-1/kafka=lkc-1/topic=orders*"},
+		{cluster.Group("billing"), "crn://confluent.cloud/organization=" + org + "/environment=env-1/cloud-cluster=lkc-1/kafka=lkc-1/group=billing"},
+		{env.CloudCluster("lkc-1").Connector("sink"), "crn://confluent.cloud/organization=" + org + "/environment=env-1/cloud-cluster=lkc-1/connector=sink"},
+		{env.SchemaRegistry("lsrc-1").Subject(crn.Wildcard), "crn://confluent.cloud/organization=" + org + "/environment=env-1/schema-registry=lsrc-1/subject=*"},
+		{env.FlinkRegion("aws.us-east-1").ComputePool("lfcp-1"), "crn://confluent.cloud/organization=" + org + "/environment=env-1/flink-region=aws.us-east-1/compute-pool=lfcp-1"},
+		{crn.New(org).IdentityProvider("op-1").IdentityPool("pool-1"), "crn://confluent.cloud/organization=" + org + "/identity-provider=op-1/identity-pool=pool-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.crn.String())
+			require.NoError(t, tt.crn.Validate())
+
+			parsed, err := crn.Parse(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, tt.crn, parsed)
+		})
+	}
+
+	// Builders copy, so siblings do not share elements.
+	topic := cluster.Topic("a")
+	group := cluster.Group("b")
+	assert.Equal(t, crn.TypeTopic, topic.Type())
+	assert.Equal(t, crn.TypeGroup, group.Type())
+	assert.Equal(t, cluster, topic.Parent())
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		crn  string
+	}{
+		{"missing scheme", "confluent.cloud/organization=" + org},
+		{"missing authority", "crn:///organization=" + org},
+		{"no organization", "crn://confluent.cloud/environment=env-1"},
+		{"not type=id", "crn://confluent.cloud/organization=" + org + "/environment"},
+		{"unknown type", "crn://confluent.cloud/organization=" + org + "/galaxy=g-1"},
+		{"wrong parent", "crn://confluent.cloud/organization=" + org + "/environment=env-1/topic=orders"},
+		{"empty id", "crn://confluent.cloud/organization=" + org + "/environment="},
+		{"kafka mismatch", "crn://confluent.cloud/organization=" + org + "/environment=env-1/cloud-cluster=lkc-1/kafka=lkc-2"},
+		{"wildcard not last", "crn://confluent.cloud/organization=" + org + "/environment=*/cloud-cluster=lkc-1"},
+		{"wildcard inside id", "crn://confluent.cloud/organization=" + org + "/environment=env-1/schema-registry=lsrc-1/subject=a*b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := crn.Parse(tt.crn)
+			assert.ErrorIs(t, err, crn.ErrInvalid)
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	env := crn.New(org).Environment("env-1")
+	cluster := env.KafkaCluster("lkc-1")
+
+	assert.True(t, env.Contains(env))
+	assert.True(t, env.Contains(cluster.Topic("orders")))
+	assert.False(t, cluster.Topic("orders").Contains(env))
+	assert.False(t, crn.New(org).Environment("env-2").Contains(cluster))
+
+	prefix := cluster.Topic(crn.Prefix("orders"))
+	assert.True(t, prefix.IsPattern())
+	assert.True(t, prefix.Contains(cluster.Topic("orders-eu")))
+	assert.True(t, prefix.Contains(cluster.Topic(crn.Prefix("orders-eu"))))
+	assert.False(t, prefix.Contains(cluster.Topic("payments")))
+	assert.False(t, prefix.Contains(cluster.Group("orders")))
+	assert.True(t, cluster.Topic(crn.Wildcard).Contains(cluster.Topic("payments")))
+}
+
+func TestGetAndText(t *testing.T) {
+	c := crn.New(org).Environment("env-1").KafkaCluster("lkc-1").Topic("orders")
+
+	id, ok := c.Get(crn.TypeEnvironment)
+	assert.True(t, ok)
+	assert.Equal(t, "env-1", id)
+	_, ok = c.Get(crn.TypeSchemaRegistry)
+	assert.False(t, ok)
+
+	data, err := json.Marshal(map[string]crn.CRN{"crn": c})
+	require.NoError(t, err)
+
+	var decoded map[string]crn.CRN
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, c, decoded["crn"])
+
+	assert.Error(t, json.Unmarshal([]byte(`{"crn":"crn://x"}`), &decoded))
+}
+
+func TestParseNamesWithSlashes(t *testing.T) {
+	env := crn.New(org).Environment("env-1")
+
+	tests := []crn.CRN{
+		env.SchemaRegistry("lsrc-1").Subject("team/orders-value"),
+		env.SchemaRegistry("lsrc-1").Subject(crn.Prefix("team/")),
+		env.KafkaCluster("lkc-1").Topic("a/b=c/d"),
+		env.CloudCluster("lkc-1").Connector("sink/s3"),
+	}
+
+	for _, c := range tests {
+		t.Run(c.String(), func(t *testing.T) {
+			parsed, err := crn.Parse(c.String())
+			require.NoError(t, err)
+			assert.Equal(t, c, parsed)
+		})
+	}
+
+	parsed, err := crn.Parse("crn://confluent.cloud/organization=" + org + "/environment=env-1/schema-registry=lsrc-1/subject=team/orders-value")
+	require.NoError(t, err)
+	assert.Equal(t, crn.Element{Type: crn.TypeSubject, Id: "team/orders-value"}, parsed.Last())
+
+	_, err = crn.Parse("crn://confluent.cloud/organization=" + org + "/environment=env-1/prod")
+	assert.ErrorIs(t, err, crn.ErrInvalid, "only names may contain slashes")
+}
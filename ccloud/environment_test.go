@@ -1,14 +1,22 @@
 package ccloud_test
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListEnvironment(t *testing.T) {
-	c := makeClient()
+	c := makeClient(t)
 	environments, err := c.ListEnvironments(&common.PaginationOptions{
 		PageSize: 1,
 	})
@@ -22,3 +30,107 @@ func TestListEnvironment(t *testing.T) {
 	assert.NotNil(t, environment)
 
 }
+
+func TestListAndGetEnvironment(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	srv.AddEnvironment("dev")
+	prodId := srv.AddEnvironment("prod")
+
+	environments, err := c.ListEnvironments(&common.PaginationOptions{PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, environments.Data, 1)
+	assert.NotEmpty(t, environments.GetPageNextToken())
+
+	environment, err := c.GetEnvironment(prodId)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", environment.DisplayName)
+}
+
+func TestCreateEnvironment(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	environment, err := c.CreateEnvironmentWithContext(context.Background(), &ccloud.EnvironmentCreateReq{DisplayName: "dev"})
+	require.NoError(t, err)
+	assert.Equal(t, "dev", environment.DisplayName)
+	assert.NotEmpty(t, environment.Metadata.ResourceName)
+
+	legacy, err := c.CreateEnvironment(&ccloud.EnvironmentCreateReq{DisplayName: "prod"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, legacy.Id)
+	assert.Equal(t, "prod", legacy.DisplayName)
+}
+
+func environmentPagesServer(t *testing.T, calls *int32) *httptest.Server {
+	pages := map[string]string{
+		"":   `{"data":[{"id":"env-1"},{"id":"env-2"}],"metadata":{"next":"%s/org/v2/environments?page_size=2&page_token=p2"}}`,
+		"p2": `{"data":[{"id":"env-3"},{"id":"env-4"}],"metadata":{"next":"%s/org/v2/environments?page_size=2&page_token=p3"}}`,
+		"p3": `{"data":[{"id":"env-5"}],"metadata":{}}`,
+	}
+
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		assert.Equal(t, "2", r.URL.Query().Get("page_size"))
+
+		page, ok := pages[r.URL.Query().Get("page_token")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, page, ts.URL)
+	}))
+	return ts
+}
+
+func TestListAllEnvironments(t *testing.T) {
+	var calls int32
+	ts := environmentPagesServer(t, &calls)
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	environments, err := client.ListAllEnvironments(context.Background(), &common.PaginationOptions{PageSize: 2})
+	assert.NoError(t, err)
+	assert.Len(t, environments, 5)
+	assert.Equal(t, "env-5", environments[4].Id)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestAllEnvironmentsStopsOnBreak(t *testing.T) {
+	var calls int32
+	ts := environmentPagesServer(t, &calls)
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	var ids []string
+	for environment, err := range client.AllEnvironments(context.Background(), &common.PaginationOptions{PageSize: 2}) {
+		assert.NoError(t, err)
+		ids = append(ids, environment.Id)
+		if len(ids) == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"env-1", "env-2", "env-3"}, ids)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestAllEnvironmentsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	environments, err := client.ListAllEnvironments(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrForbidden)
+	assert.Nil(t, environments)
+}
@@ -1,9 +1,8 @@
 package ccloud
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
@@ -18,74 +17,55 @@ type EnvironmentList struct {
 	Data []Environment `json:"data"`
 }
 
-func (c *ConfluentClient) ListEnvironments(opt *common.PaginationOptions) (*EnvironmentList, error) {
-	urlPath := "/org/v2/environments"
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClient) environments() common.Resource[Environment, EnvironmentList] {
+	return common.Resource[Environment, EnvironmentList]{
+		Send: c.send,
+		Api:  "org",
+		Kind: "Environment",
+		Path: "/org/v2/environments",
 	}
+}
 
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list environments: %s", req.Status)
-	}
+func (c *ConfluentClient) ListEnvironments(opt *common.PaginationOptions) (*EnvironmentList, error) {
+	return c.ListEnvironmentsWithContext(context.Background(), opt)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClient) ListEnvironmentsWithContext(ctx context.Context, opt *common.PaginationOptions) (*EnvironmentList, error) {
+	return c.environments().List(ctx, opt)
+}
 
-	var environments EnvironmentList
-	err = json.NewDecoder(req.Body).Decode(&environments)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClient) AllEnvironments(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[Environment, error] {
+	return c.environments().All(ctx, opt)
+}
 
-	return &environments, nil
+func (c *ConfluentClient) ListAllEnvironments(ctx context.Context, opt *common.PaginationOptions) ([]Environment, error) {
+	return common.Collect(c.AllEnvironments(ctx, opt))
 }
 
 func (c *ConfluentClient) GetEnvironment(environmentId string) (*Environment, error) {
-	urlPath := fmt.Sprintf("/org/v2/environments/%s", environmentId)
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get environment: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var environment Environment
-	err = json.NewDecoder(req.Body).Decode(&environment)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetEnvironmentWithContext(context.Background(), environmentId)
+}
 
-	return &environment, nil
+func (c *ConfluentClient) GetEnvironmentWithContext(ctx context.Context, environmentId string) (*Environment, error) {
+	return c.environments().Get(ctx, environmentId, nil)
 }
 
 type EnvironmentCreateReq struct {
 	DisplayName string `json:"display_name"`
 }
 
+// CreateEnvironment returns the created environment as a ServiceAccount, a
+// signature kept for compatibility. Prefer CreateEnvironmentWithContext.
 func (c *ConfluentClient) CreateEnvironment(create *EnvironmentCreateReq) (*ServiceAccount, error) {
-	urlPath := "/org/v2/environments"
-	req, err := c.doRequest(urlPath, http.MethodPost, create, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusCreated != req.StatusCode {
-		return nil, fmt.Errorf("failed to create environment: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var serviceAccount ServiceAccount
-	err = json.NewDecoder(req.Body).Decode(&serviceAccount)
-	if err != nil {
+	environment, err := c.CreateEnvironmentWithContext(context.Background(), create)
+	if environment == nil {
 		return nil, err
 	}
+	return &ServiceAccount{BaseModel: environment.BaseModel, DisplayName: environment.DisplayName}, err
+}
 
-	return &serviceAccount, nil
+func (c *ConfluentClient) CreateEnvironmentWithContext(ctx context.Context, create *EnvironmentCreateReq) (*Environment, error) {
+	return c.environments().Create(ctx, create, nil)
 }
 
 type EnvironmentUpdateReq struct {
@@ -93,37 +73,17 @@ type EnvironmentUpdateReq struct {
 }
 
 func (c *ConfluentClient) UpdateEnvironment(environmentId string, update *EnvironmentUpdateReq) (*Environment, error) {
-	urlPath := fmt.Sprintf("/org/v2/environments/%s", environmentId)
-	req, err := c.doRequest(urlPath, http.MethodPatch, update, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get environment: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var environment Environment
-	err = json.NewDecoder(req.Body).Decode(&environment)
-	if err != nil {
-		return nil, err
-	}
+	return c.UpdateEnvironmentWithContext(context.Background(), environmentId, update)
+}
 
-	return &environment, nil
+func (c *ConfluentClient) UpdateEnvironmentWithContext(ctx context.Context, environmentId string, update *EnvironmentUpdateReq) (*Environment, error) {
+	return c.environments().Update(ctx, environmentId, update, nil)
 }
 
 func (c *ConfluentClient) DeleteEnvironment(environmentId string) error {
-	urlPath := fmt.Sprintf("/org/v2/environments/%s", environmentId)
-	req, err := c.doRequest(urlPath, http.MethodDelete, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusOK != req.StatusCode && http.StatusNoContent != req.StatusCode {
-		return fmt.Errorf("failed to delete environment: %s", req.Status)
-	}
+	return c.DeleteEnvironmentWithContext(context.Background(), environmentId)
+}
 
-	return nil
+func (c *ConfluentClient) DeleteEnvironmentWithContext(ctx context.Context, environmentId string) error {
+	return c.environments().Delete(ctx, environmentId, nil)
 }
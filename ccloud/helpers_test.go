@@ -0,0 +1,79 @@
+package ccloud_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/cassette"
+	"github.com/electric-saw/ccloud-client-go/ccloud/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeClient(t *testing.T) *ccloud.ConfluentClient {
+	client := ccloud.NewClient(newRecorder(t).ClientOptions()...)
+	if auth, err := credentials.Auth(credentials.Default(), credentials.Cloud); err == nil {
+		client.WithAuth(auth)
+	}
+	return client
+}
+
+// newRecorder replays testdata/cassettes/<test>.json. The test is skipped
+// until the cassette is recorded, by running it with CCLOUD_CASSETTE=record
+// and real credentials.
+func newRecorder(t *testing.T) *cassette.Recorder {
+	recorder, err := cassette.New(
+		filepath.Join("testdata", "cassettes", t.Name()+".json"),
+		cassette.ModeFromEnv(),
+		cassette.WithScrubber(scrubTestEnv),
+	)
+	if errors.Is(err, cassette.ErrNoCassette) {
+		t.Skip(err)
+	}
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, recorder.Stop())
+	})
+
+	return recorder
+}
+
+var (
+	testEnvMu       sync.Mutex
+	testEnvReplaced = map[string]string{}
+)
+
+// testEnv returns the variable key when talking to the real API and the value
+// the cassettes were recorded with when replaying. Recorded cassettes have the
+// real value replaced so that they replay with the same one.
+func testEnv(key, recorded string) string {
+	if cassette.ModeFromEnv() == cassette.ModeReplay {
+		return recorded
+	}
+
+	value := os.Getenv(key)
+	if value != "" {
+		testEnvMu.Lock()
+		testEnvReplaced[value] = recorded
+		testEnvMu.Unlock()
+	}
+	return value
+}
+
+func scrubTestEnv(interaction *cassette.Interaction) {
+	testEnvMu.Lock()
+	defer testEnvMu.Unlock()
+
+	for value, recorded := range testEnvReplaced {
+		replacer := strings.NewReplacer(value, recorded)
+		interaction.Request.Url = replacer.Replace(interaction.Request.Url)
+		interaction.Request.Body = []byte(replacer.Replace(string(interaction.Request.Body)))
+		interaction.Response.Body = []byte(replacer.Replace(string(interaction.Response.Body)))
+	}
+}
@@ -1,11 +1,11 @@
 package ccloud
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
+	"iter"
+	"log/slog"
 
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
 
@@ -17,11 +17,20 @@ type ApiKeySpec struct {
 	Secret      string `json:"secret,omitempty"`
 }
 
+// LogValue keeps the secret out of structured logs.
+func (s ApiKeySpec) LogValue() slog.Value {
+	return client.RedactedLogValue(s)
+}
+
 type ApiKey struct {
 	common.BaseModel
 	Spec ApiKeySpec `json:"spec"`
 }
 
+func (k ApiKey) LogValue() slog.Value {
+	return client.RedactedLogValue(k)
+}
+
 type ApiKeyList struct {
 	common.BaseModel
 	Data []ApiKey `json:"data"`
@@ -50,108 +59,60 @@ type ApiKeyCreateReq struct {
 	Resource    ApiKeyCommonReq `json:"resource,omitempty"`
 }
 
-func (c *ConfluentClient) ListApiKeys(opt *ApiKeyListOptions) (*ApiKeyList, error) {
-	urlPath := "/iam/v2/api-keys"
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClient) apiKeys() common.Resource[ApiKey, ApiKeyList] {
+	return common.Resource[ApiKey, ApiKeyList]{
+		Send: c.send,
+		Api:  "iam",
+		Kind: "ApiKey",
+		Path: "/iam/v2/api-keys",
+		Spec: true,
 	}
+}
 
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list api keys: %s", req.Status)
-	}
+func (c *ConfluentClient) ListApiKeys(opt *ApiKeyListOptions) (*ApiKeyList, error) {
+	return c.ListApiKeysWithContext(context.Background(), opt)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClient) ListApiKeysWithContext(ctx context.Context, opt *ApiKeyListOptions) (*ApiKeyList, error) {
+	return c.apiKeys().List(ctx, opt)
+}
 
-	var apiKeys ApiKeyList
-	err = json.NewDecoder(req.Body).Decode(&apiKeys)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClient) AllApiKeys(ctx context.Context, opt *ApiKeyListOptions) iter.Seq2[ApiKey, error] {
+	return c.apiKeys().All(ctx, opt)
+}
 
-	return &apiKeys, nil
+func (c *ConfluentClient) ListAllApiKeys(ctx context.Context, opt *ApiKeyListOptions) ([]ApiKey, error) {
+	return common.Collect(c.AllApiKeys(ctx, opt))
 }
 
 func (c *ConfluentClient) GetApiKey(apyKeyId string) (*ApiKey, error) {
-	urlPath := fmt.Sprintf("/iam/v2/api-keys/%s", apyKeyId)
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get api-key: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var apiKey ApiKey
-	err = json.NewDecoder(req.Body).Decode(&apiKey)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetApiKeyWithContext(context.Background(), apyKeyId)
+}
 
-	return &apiKey, nil
+func (c *ConfluentClient) GetApiKeyWithContext(ctx context.Context, apyKeyId string) (*ApiKey, error) {
+	return c.apiKeys().Get(ctx, apyKeyId, nil)
 }
 
 func (c *ConfluentClient) CreateApiKey(create *ApiKeyCreateReq) (*ApiKey, error) {
-	urlPath := "/iam/v2/api-keys"
-	req, err := c.doRequest(urlPath, http.MethodPost, specWrap{create}, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	defer req.Body.Close()
-
-	if http.StatusAccepted != req.StatusCode {
-		data, _ := io.ReadAll(req.Body)
-		fmt.Println(string(data))
-		return nil, fmt.Errorf("failed to create api-key: %s", req.Status)
-	}
-
-	var ApiKey ApiKey
-	err = json.NewDecoder(req.Body).Decode(&ApiKey)
-	if err != nil {
-		return nil, err
-	}
+	return c.CreateApiKeyWithContext(context.Background(), create)
+}
 
-	return &ApiKey, nil
+func (c *ConfluentClient) CreateApiKeyWithContext(ctx context.Context, create *ApiKeyCreateReq) (*ApiKey, error) {
+	return c.apiKeys().Create(ctx, create, nil)
 }
 
 func (c *ConfluentClient) DeleteApiKey(id string) error {
-	urlPath := fmt.Sprintf("/iam/v2/api-keys/%s", id)
-	req, err := c.doRequest(urlPath, http.MethodDelete, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusNoContent != req.StatusCode {
-		return fmt.Errorf("failed to delete api-key: %s", req.Status)
-	}
-
-	defer req.Body.Close()
+	return c.DeleteApiKeyWithContext(context.Background(), id)
+}
 
-	return nil
+func (c *ConfluentClient) DeleteApiKeyWithContext(ctx context.Context, id string) error {
+	return c.apiKeys().Delete(ctx, id, nil)
 }
 
 func (c *ConfluentClient) UpdateApiKey(apyKeyId string, update *ApiKeyUpdateReq) (*ApiKey, error) {
-	urlPath := fmt.Sprintf("/iam/v2/api-keys/%s", apyKeyId)
-	req, err := c.doRequest(urlPath, http.MethodPatch, specWrap{update}, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to update api-key: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var apiKey ApiKey
-	err = json.NewDecoder(req.Body).Decode(&apiKey)
-	if err != nil {
-		return nil, err
-	}
+	return c.UpdateApiKeyWithContext(context.Background(), apyKeyId, update)
+}
 
-	return &apiKey, nil
+func (c *ConfluentClient) UpdateApiKeyWithContext(ctx context.Context, apyKeyId string, update *ApiKeyUpdateReq) (*ApiKey, error) {
+	return c.apiKeys().Update(ctx, apyKeyId, update, nil)
 }
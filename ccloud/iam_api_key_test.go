@@ -1,16 +1,20 @@
 package ccloud_test
 
 import (
+	"bytes"
 	"fmt"
+	"log/slog"
 	"testing"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListApiKeys(t *testing.T) {
-	c := makeClient()
+	c := makeClient(t)
 	pageToken := ""
 
 	result := []ccloud.ApiKey{}
@@ -62,7 +66,7 @@ func TestListApiKeys(t *testing.T) {
 }
 
 func TestCreateUpdateDeleteApiKey(t *testing.T) {
-	c := makeClient()
+	c := makeClient(t)
 	apiKeys, err := c.ListApiKeys(
 		&ccloud.ApiKeyListOptions{
 			PaginationOptions: common.PaginationOptions{
@@ -110,3 +114,47 @@ func TestCreateUpdateDeleteApiKey(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestApiKeyLifecycle(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	saId := srv.AddServiceAccount("ci")
+	clusterId, err := srv.AddKafkaCluster(srv.AddEnvironment("dev"), "orders")
+	require.NoError(t, err)
+
+	created, err := c.CreateApiKey(&ccloud.ApiKeyCreateReq{
+		DisplayName: "test-api-key",
+		Owner:       ccloud.ApiKeyCommonReq{Id: saId},
+		Resource:    ccloud.ApiKeyCommonReq{Id: clusterId},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Spec.Secret)
+
+	apiKeys, err := c.ListApiKeys(&ccloud.ApiKeyListOptions{Owner: saId, Resource: clusterId})
+	require.NoError(t, err)
+	require.Len(t, apiKeys.Data, 1)
+	assert.Equal(t, created.Id, apiKeys.Data[0].Id)
+
+	updated, err := c.UpdateApiKey(created.Id, &ccloud.ApiKeyUpdateReq{DisplayName: "test", Description: "test description"})
+	require.NoError(t, err)
+	assert.Equal(t, "test", updated.Spec.DisplayName)
+	assert.Equal(t, "test description", updated.Spec.Description)
+
+	require.NoError(t, c.DeleteApiKey(created.Id))
+	_, err = c.GetApiKey(created.Id)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
+
+func TestApiKeyLogValueRedactsSecret(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	apiKey := ccloud.ApiKey{Spec: ccloud.ApiKeySpec{DisplayName: "ci", Secret: "s3cr3t"}}
+	apiKey.Id = "ABCKEY"
+	logger.Info("created", "api_key", apiKey)
+
+	assert.Contains(t, buf.String(), "ABCKEY")
+	assert.NotContains(t, buf.String(), "s3cr3t")
+}
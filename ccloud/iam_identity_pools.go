@@ -0,0 +1,104 @@
+package ccloud
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+)
+
+type IdentityPool struct {
+	common.BaseModel
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	// IdentityClaim names the token claim identifying a workload, e.g.
+	// "claims.sub".
+	IdentityClaim string `json:"identity_claim"`
+	// Filter is the CEL expression a token must satisfy to use the pool,
+	// e.g. `claims.aud == "confluent"`.
+	Filter string `json:"filter"`
+	State  string `json:"state,omitempty"`
+}
+
+// Principal returns the principal of the pool in role bindings.
+func (p *IdentityPool) Principal() string {
+	return UserPrincipal(p.Id)
+}
+
+type IdentityPoolList struct {
+	common.BaseModel
+	Data []IdentityPool `json:"data"`
+}
+
+func (c *ConfluentClient) identityPools(identityProviderId string) common.Resource[IdentityPool, IdentityPoolList] {
+	return common.Resource[IdentityPool, IdentityPoolList]{
+		Send: c.send,
+		Api:  "iam",
+		Kind: "IdentityPool",
+		Path: fmt.Sprintf("/iam/v2/identity-providers/%s/identity-pools", identityProviderId),
+	}
+}
+
+func (c *ConfluentClient) ListIdentityPools(identityProviderId string, opt *common.PaginationOptions) (*IdentityPoolList, error) {
+	return c.ListIdentityPoolsWithContext(context.Background(), identityProviderId, opt)
+}
+
+func (c *ConfluentClient) ListIdentityPoolsWithContext(ctx context.Context, identityProviderId string, opt *common.PaginationOptions) (*IdentityPoolList, error) {
+	return c.identityPools(identityProviderId).List(ctx, opt)
+}
+
+func (c *ConfluentClient) AllIdentityPools(ctx context.Context, identityProviderId string, opt *common.PaginationOptions) iter.Seq2[IdentityPool, error] {
+	return c.identityPools(identityProviderId).All(ctx, opt)
+}
+
+func (c *ConfluentClient) ListAllIdentityPools(ctx context.Context, identityProviderId string, opt *common.PaginationOptions) ([]IdentityPool, error) {
+	return common.Collect(c.AllIdentityPools(ctx, identityProviderId, opt))
+}
+
+func (c *ConfluentClient) GetIdentityPool(identityProviderId, identityPoolId string) (*IdentityPool, error) {
+	return c.GetIdentityPoolWithContext(context.Background(), identityProviderId, identityPoolId)
+}
+
+func (c *ConfluentClient) GetIdentityPoolWithContext(ctx context.Context, identityProviderId, identityPoolId string) (*IdentityPool, error) {
+	return c.identityPools(identityProviderId).Get(ctx, identityPoolId, nil)
+}
+
+type IdentityPoolCreateReq struct {
+	DisplayName   string `json:"display_name"`
+	Description   string `json:"description,omitempty"`
+	IdentityClaim string `json:"identity_claim"`
+	Filter        string `json:"filter"`
+}
+
+func (c *ConfluentClient) CreateIdentityPool(identityProviderId string, create *IdentityPoolCreateReq) (*IdentityPool, error) {
+	return c.CreateIdentityPoolWithContext(context.Background(), identityProviderId, create)
+}
+
+func (c *ConfluentClient) CreateIdentityPoolWithContext(ctx context.Context, identityProviderId string, create *IdentityPoolCreateReq) (*IdentityPool, error) {
+	return c.identityPools(identityProviderId).Create(ctx, create, nil)
+}
+
+// IdentityPoolUpdateReq patches the fields that are set.
+type IdentityPoolUpdateReq struct {
+	DisplayName   string `json:"display_name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	IdentityClaim string `json:"identity_claim,omitempty"`
+	Filter        string `json:"filter,omitempty"`
+}
+
+func (c *ConfluentClient) UpdateIdentityPool(identityProviderId, identityPoolId string, update *IdentityPoolUpdateReq) (*IdentityPool, error) {
+	return c.UpdateIdentityPoolWithContext(context.Background(), identityProviderId, identityPoolId, update)
+}
+
+func (c *ConfluentClient) UpdateIdentityPoolWithContext(ctx context.Context, identityProviderId, identityPoolId string, update *IdentityPoolUpdateReq) (*IdentityPool, error) {
+	return c.identityPools(identityProviderId).Update(ctx, identityPoolId, update, nil)
+}
+
+func (c *ConfluentClient) DeleteIdentityPool(identityProviderId, identityPoolId string) error {
+	return c.DeleteIdentityPoolWithContext(context.Background(), identityProviderId, identityPoolId)
+}
+
+func (c *ConfluentClient) DeleteIdentityPoolWithContext(ctx context.Context, identityProviderId, identityPoolId string) error {
+	return c.identityPools(identityProviderId).Delete(ctx, identityPoolId, nil)
+}
@@ -0,0 +1,97 @@
+package ccloud_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAllIdentityPools(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"data":[{"id":"pool-1","identity_claim":"claims.sub","filter":"claims.aud == \"kafka\""},{"id":"pool-2"}],"metadata":{"next":"%s/iam/v2/identity-providers/op-1/identity-pools?page_size=2&page_token=p2"}}`,
+		"p2": `{"data":[{"id":"pool-3"}],"metadata":{}}`,
+	}
+
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/iam/v2/identity-providers/op-1/identity-pools", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("page_size"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, pages[r.URL.Query().Get("page_token")], ts.URL)
+	}))
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	pools, err := client.ListAllIdentityPools(context.Background(), "op-1", &common.PaginationOptions{PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, pools, 3)
+	assert.Equal(t, "claims.sub", pools[0].IdentityClaim)
+	assert.Equal(t, `claims.aud == "kafka"`, pools[0].Filter)
+	assert.Equal(t, "pool-3", pools[2].Id)
+}
+
+func TestCreateIdentityPool(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/iam/v2/identity-providers/op-1/identity-pools", r.URL.Path)
+
+		var req ccloud.IdentityPoolCreateReq
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "claims.sub", req.IdentityClaim)
+		assert.Equal(t, `claims.aud == "kafka"`, req.Filter)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(ccloud.IdentityPool{
+			BaseModel:     common.BaseModel{Id: "pool-1"},
+			DisplayName:   req.DisplayName,
+			IdentityClaim: req.IdentityClaim,
+			Filter:        req.Filter,
+		})
+	}))
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	pool, err := client.CreateIdentityPool("op-1", &ccloud.IdentityPoolCreateReq{
+		DisplayName:   "payments",
+		IdentityClaim: "claims.sub",
+		Filter:        `claims.aud == "kafka"`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pool-1", pool.Id)
+	assert.Equal(t, "User:pool-1", pool.Principal())
+}
+
+func TestIdentityPoolAsRoleBindingPrincipal(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	pool := &ccloud.IdentityPool{BaseModel: common.BaseModel{Id: "pool-1"}}
+	envCrn := crn.New(ccloudtest.OrganizationId).Environment(srv.AddEnvironment("dev"))
+
+	rb, err := client.CreateRoleBinding(ccloud.NewRoleBindingCreateReq(pool.Principal(), "EnvironmentAdmin", envCrn))
+	require.NoError(t, err)
+	assert.Equal(t, "User:pool-1", rb.Principal)
+
+	bindings, err := client.ListAllRoleBindings(context.Background(), &ccloud.ListRoleBindingsQuery{
+		Principal:  ccloud.UserPrincipal("pool-1"),
+		CrnPattern: envCrn.String(),
+	})
+	require.NoError(t, err)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, rb.Id, bindings[0].Id)
+}
@@ -0,0 +1,95 @@
+package ccloud
+
+import (
+	"context"
+	"iter"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+)
+
+type IdentityProvider struct {
+	common.BaseModel
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	State       string `json:"state,omitempty"`
+	Issuer      string `json:"issuer"`
+	JwksUri     string `json:"jwks_uri"`
+}
+
+type IdentityProviderList struct {
+	common.BaseModel
+	Data []IdentityProvider `json:"data"`
+}
+
+func (c *ConfluentClient) identityProviders() common.Resource[IdentityProvider, IdentityProviderList] {
+	return common.Resource[IdentityProvider, IdentityProviderList]{
+		Send: c.send,
+		Api:  "iam",
+		Kind: "IdentityProvider",
+		Path: "/iam/v2/identity-providers",
+	}
+}
+
+func (c *ConfluentClient) ListIdentityProviders(opt *common.PaginationOptions) (*IdentityProviderList, error) {
+	return c.ListIdentityProvidersWithContext(context.Background(), opt)
+}
+
+func (c *ConfluentClient) ListIdentityProvidersWithContext(ctx context.Context, opt *common.PaginationOptions) (*IdentityProviderList, error) {
+	return c.identityProviders().List(ctx, opt)
+}
+
+func (c *ConfluentClient) AllIdentityProviders(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[IdentityProvider, error] {
+	return c.identityProviders().All(ctx, opt)
+}
+
+func (c *ConfluentClient) ListAllIdentityProviders(ctx context.Context, opt *common.PaginationOptions) ([]IdentityProvider, error) {
+	return common.Collect(c.AllIdentityProviders(ctx, opt))
+}
+
+func (c *ConfluentClient) GetIdentityProvider(identityProviderId string) (*IdentityProvider, error) {
+	return c.GetIdentityProviderWithContext(context.Background(), identityProviderId)
+}
+
+func (c *ConfluentClient) GetIdentityProviderWithContext(ctx context.Context, identityProviderId string) (*IdentityProvider, error) {
+	return c.identityProviders().Get(ctx, identityProviderId, nil)
+}
+
+type IdentityProviderCreateReq struct {
+	DisplayName string `json:"display_name"`
+	Description string `json:"description,omitempty"`
+	Issuer      string `json:"issuer"`
+	JwksUri     string `json:"jwks_uri"`
+}
+
+func (c *ConfluentClient) CreateIdentityProvider(create *IdentityProviderCreateReq) (*IdentityProvider, error) {
+	return c.CreateIdentityProviderWithContext(context.Background(), create)
+}
+
+func (c *ConfluentClient) CreateIdentityProviderWithContext(ctx context.Context, create *IdentityProviderCreateReq) (*IdentityProvider, error) {
+	return c.identityProviders().Create(ctx, create, nil)
+}
+
+// IdentityProviderUpdateReq patches the fields that are set.
+type IdentityProviderUpdateReq struct {
+	DisplayName string `json:"display_name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Issuer      string `json:"issuer,omitempty"`
+	JwksUri     string `json:"jwks_uri,omitempty"`
+}
+
+func (c *ConfluentClient) UpdateIdentityProvider(identityProviderId string, update *IdentityProviderUpdateReq) (*IdentityProvider, error) {
+	return c.UpdateIdentityProviderWithContext(context.Background(), identityProviderId, update)
+}
+
+func (c *ConfluentClient) UpdateIdentityProviderWithContext(ctx context.Context, identityProviderId string, update *IdentityProviderUpdateReq) (*IdentityProvider, error) {
+	return c.identityProviders().Update(ctx, identityProviderId, update, nil)
+}
+
+// DeleteIdentityProvider deletes a provider along with its identity pools.
+func (c *ConfluentClient) DeleteIdentityProvider(identityProviderId string) error {
+	return c.DeleteIdentityProviderWithContext(context.Background(), identityProviderId)
+}
+
+func (c *ConfluentClient) DeleteIdentityProviderWithContext(ctx context.Context, identityProviderId string) error {
+	return c.identityProviders().Delete(ctx, identityProviderId, nil)
+}
@@ -0,0 +1,86 @@
+package ccloud_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityProviderCrud(t *testing.T) {
+	type call struct {
+		method, path string
+		body         map[string]any
+	}
+	var calls []call
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			require.NoError(t, json.Unmarshal(data, &body))
+		}
+		calls = append(calls, call{r.Method, r.URL.Path, body})
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"op-1","display_name":"okta","issuer":"https://okta.example.com","jwks_uri":"https://okta.example.com/keys"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"op-1","display_name":"okta","issuer":"https://okta.example.com","jwks_uri":"https://okta.example.com/keys"}`))
+		}
+	}))
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	created, err := client.CreateIdentityProvider(&ccloud.IdentityProviderCreateReq{
+		DisplayName: "okta",
+		Issuer:      "https://okta.example.com",
+		JwksUri:     "https://okta.example.com/keys",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "op-1", created.Id)
+	assert.Equal(t, "https://okta.example.com/keys", created.JwksUri)
+
+	provider, err := client.GetIdentityProvider("op-1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://okta.example.com", provider.Issuer)
+
+	_, err = client.UpdateIdentityProvider("op-1", &ccloud.IdentityProviderUpdateReq{Description: "workloads"})
+	require.NoError(t, err)
+
+	require.NoError(t, client.DeleteIdentityProvider("op-1"))
+
+	require.Len(t, calls, 4)
+	assert.Equal(t, call{http.MethodPost, "/iam/v2/identity-providers", map[string]any{
+		"display_name": "okta",
+		"issuer":       "https://okta.example.com",
+		"jwks_uri":     "https://okta.example.com/keys",
+	}}, calls[0])
+	assert.Equal(t, call{http.MethodGet, "/iam/v2/identity-providers/op-1", nil}, calls[1])
+	assert.Equal(t, call{http.MethodPatch, "/iam/v2/identity-providers/op-1", map[string]any{"description": "workloads"}}, calls[2])
+	assert.Equal(t, call{http.MethodDelete, "/iam/v2/identity-providers/op-1", nil}, calls[3])
+}
+
+func TestGetIdentityProviderNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"status":"404","detail":"not found"}]}`))
+	}))
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	_, err := client.GetIdentityProvider("op-missing")
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	assert.ErrorContains(t, err, "failed to get identity provider")
+}
@@ -0,0 +1,179 @@
+package ccloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+)
+
+const (
+	InvitationStatusSent     = "INVITE_STATUS_SENT"
+	InvitationStatusAccepted = "INVITE_STATUS_ACCEPTED"
+	InvitationStatusExpired  = "INVITE_STATUS_EXPIRED"
+	InvitationStatusDeleted  = "INVITE_STATUS_DELETED"
+
+	AuthTypeLocal = "AUTH_TYPE_LOCAL"
+	AuthTypeSso   = "AUTH_TYPE_SSO"
+)
+
+// DryRunUserId stands for the user of an invitation planned by a dry run.
+const DryRunUserId = "u-dry-run"
+
+type Invitation struct {
+	common.BaseModel
+	Email      string `json:"email"`
+	AuthType   string `json:"auth_type,omitempty"`
+	Status     string `json:"status"`
+	AcceptedAt string `json:"accepted_at,omitempty"`
+	ExpiresAt  string `json:"expires_at,omitempty"`
+	// User is the user created by the invitation, it exists before the
+	// invitation is accepted.
+	User    common.BaseModel `json:"user"`
+	Creator common.BaseModel `json:"creator"`
+}
+
+func (i *Invitation) Pending() bool {
+	return i.Status == InvitationStatusSent
+}
+
+func (i *Invitation) Accepted() bool {
+	return i.Status == InvitationStatusAccepted
+}
+
+type InvitationList struct {
+	common.BaseModel
+	Data []Invitation `json:"data"`
+}
+
+func (c *ConfluentClient) invitations() common.Resource[Invitation, InvitationList] {
+	return common.Resource[Invitation, InvitationList]{
+		Send: c.send,
+		Api:  "iam",
+		Kind: "Invitation",
+		Path: "/iam/v2/invitations",
+	}
+}
+
+func (c *ConfluentClient) ListInvitations(opt *common.PaginationOptions) (*InvitationList, error) {
+	return c.ListInvitationsWithContext(context.Background(), opt)
+}
+
+func (c *ConfluentClient) ListInvitationsWithContext(ctx context.Context, opt *common.PaginationOptions) (*InvitationList, error) {
+	return c.invitations().List(ctx, opt)
+}
+
+func (c *ConfluentClient) AllInvitations(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[Invitation, error] {
+	return c.invitations().All(ctx, opt)
+}
+
+func (c *ConfluentClient) ListAllInvitations(ctx context.Context, opt *common.PaginationOptions) ([]Invitation, error) {
+	return common.Collect(c.AllInvitations(ctx, opt))
+}
+
+// ListInvitationsWithStatus lists the invitations in any of statuses, e.g.
+// InvitationStatusSent for the pending ones. The API has no status filter,
+// so every invitation is fetched.
+func (c *ConfluentClient) ListInvitationsWithStatus(ctx context.Context, statuses ...string) ([]Invitation, error) {
+	var invitations []Invitation
+	for invitation, err := range c.AllInvitations(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(statuses, invitation.Status) {
+			invitations = append(invitations, invitation)
+		}
+	}
+	return invitations, nil
+}
+
+func (c *ConfluentClient) GetInvitation(invitationId string) (*Invitation, error) {
+	return c.GetInvitationWithContext(context.Background(), invitationId)
+}
+
+func (c *ConfluentClient) GetInvitationWithContext(ctx context.Context, invitationId string) (*Invitation, error) {
+	return c.invitations().Get(ctx, invitationId, nil)
+}
+
+type InvitationCreateReq struct {
+	Email    string `json:"email"`
+	AuthType string `json:"auth_type,omitempty"`
+}
+
+func (c *ConfluentClient) CreateInvitation(create *InvitationCreateReq) (*Invitation, error) {
+	return c.CreateInvitationWithContext(context.Background(), create)
+}
+
+func (c *ConfluentClient) CreateInvitationWithContext(ctx context.Context, create *InvitationCreateReq) (*Invitation, error) {
+	return c.invitations().Create(ctx, create, nil)
+}
+
+// DeleteInvitation revokes an invitation. Once accepted, the user has to be
+// removed instead, see Offboard.
+func (c *ConfluentClient) DeleteInvitation(invitationId string) error {
+	return c.DeleteInvitationWithContext(context.Background(), invitationId)
+}
+
+func (c *ConfluentClient) DeleteInvitationWithContext(ctx context.Context, invitationId string) error {
+	return c.invitations().Delete(ctx, invitationId, nil)
+}
+
+// RoleGrant is a role binding of a principal that does not exist yet.
+type RoleGrant struct {
+	RoleName   string
+	CrnPattern crn.CRN
+}
+
+// InvitationReport holds the invitation and the outcome of each of its role
+// bindings.
+type InvitationReport struct {
+	Invitation   *Invitation
+	RoleBindings *common.BulkReport[*RoleBindingCreateReq, *RoleBinding]
+}
+
+func (r *InvitationReport) Err() error {
+	if err := r.RoleBindings.Err(); err != nil {
+		return fmt.Errorf("failed to create role bindings of %s: %w", r.Invitation.Email, err)
+	}
+	return nil
+}
+
+// InviteUser invites email and binds grants to the user created by the
+// invitation, so that access is ready once it is accepted. Failed bindings
+// do not revoke the invitation; they are reported and can be retried with
+// ApplyRoleBindings. It fails without binding anything when the invitation
+// comes back without a user.
+//
+// With client.WithDryRun, the user does not exist yet, so the bindings are
+// planned for the principal of DryRunUserId.
+func (c *ConfluentClient) InviteUser(ctx context.Context, create *InvitationCreateReq, grants []RoleGrant, opts ...common.BulkOption) (*InvitationReport, error) {
+	invitation, err := c.CreateInvitationWithContext(ctx, create)
+	if errors.Is(err, client.ErrDryRun) {
+		invitation = &Invitation{
+			Email:    create.Email,
+			AuthType: create.AuthType,
+			Status:   InvitationStatusSent,
+			User:     common.BaseModel{Id: DryRunUserId},
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if invitation.User.Id == "" {
+		return nil, fmt.Errorf("failed to invite %s: invitation %s has no user to bind roles to", create.Email, invitation.Id)
+	}
+
+	reqs := make([]*RoleBindingCreateReq, len(grants))
+	for i, grant := range grants {
+		reqs[i] = NewRoleBindingCreateReq(UserPrincipal(invitation.User.Id), grant.RoleName, grant.CrnPattern)
+	}
+
+	return &InvitationReport{
+		Invitation:   invitation,
+		RoleBindings: c.createRoleBindings(ctx, reqs, opts...),
+	}, nil
+}
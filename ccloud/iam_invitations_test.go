@@ -0,0 +1,157 @@
+package ccloud_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInviteUser(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	envCrn := crn.New(ccloudtest.OrganizationId).Environment(srv.AddEnvironment("dev"))
+
+	report, err := client.InviteUser(context.Background(), &ccloud.InvitationCreateReq{Email: "jane@example.com"}, []ccloud.RoleGrant{
+		{RoleName: "EnvironmentAdmin", CrnPattern: envCrn},
+		{RoleName: "MetricsViewer", CrnPattern: crn.New(ccloudtest.OrganizationId)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+	assert.True(t, report.Invitation.Pending())
+	assert.Equal(t, "jane@example.com", report.Invitation.Email)
+	assert.Len(t, report.RoleBindings.Succeeded(), 2)
+
+	bindings, err := client.ListAllRoleBindings(context.Background(), &ccloud.ListRoleBindingsQuery{
+		Principal:  ccloud.UserPrincipal(report.Invitation.User.Id),
+		CrnPattern: crn.New(ccloudtest.OrganizationId).String(),
+	})
+	require.NoError(t, err)
+	assert.Len(t, bindings, 2)
+
+	_, err = client.CreateInvitation(&ccloud.InvitationCreateReq{Email: "jane@example.com"})
+	assert.ErrorIs(t, err, common.ErrConflict)
+}
+
+func TestInviteUserReportsFailedRoleBindings(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	report, err := client.InviteUser(context.Background(), &ccloud.InvitationCreateReq{Email: "jane@example.com"}, []ccloud.RoleGrant{
+		{RoleName: "", CrnPattern: crn.New(ccloudtest.OrganizationId)},
+	})
+	require.NoError(t, err)
+	assert.Len(t, report.RoleBindings.Failed(), 1)
+	assert.ErrorContains(t, report.Err(), "failed to create role bindings of jane@example.com")
+
+	_, err = client.GetInvitation(report.Invitation.Id)
+	assert.NoError(t, err, "the invitation is kept")
+}
+
+func TestInviteUserWithoutUser(t *testing.T) {
+	var bindings int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/iam/v2/role-bindings" {
+			bindings++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"i-1","email":"jane@example.com","status":"INVITE_STATUS_SENT"}`))
+	}))
+	defer ts.Close()
+
+	c := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	_, err := c.InviteUser(context.Background(), &ccloud.InvitationCreateReq{Email: "jane@example.com"}, []ccloud.RoleGrant{
+		{RoleName: "MetricsViewer", CrnPattern: crn.New(ccloudtest.OrganizationId)},
+	})
+	assert.ErrorContains(t, err, "invitation i-1 has no user")
+	assert.Zero(t, bindings, "no binding is made for an empty principal")
+}
+
+func TestInviteUserDryRun(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	plan := client.NewPlan()
+	c := srv.Client(client.WithDryRun(plan))
+
+	report, err := c.InviteUser(context.Background(), &ccloud.InvitationCreateReq{Email: "jane@example.com"}, []ccloud.RoleGrant{
+		{RoleName: "MetricsViewer", CrnPattern: crn.New(ccloudtest.OrganizationId)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+	assert.Equal(t, ccloud.DryRunUserId, report.Invitation.User.Id)
+
+	ops := plan.Operations()
+	require.Len(t, ops, 2)
+	assert.Equal(t, "/iam/v2/invitations", ops[0].Path)
+	assert.Equal(t, "/iam/v2/role-bindings", ops[1].Path)
+	assert.Contains(t, string(ops[1].Body), `"principal":"User:`+ccloud.DryRunUserId+`"`)
+
+	invitations, err := srv.Client().ListAllInvitations(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, invitations)
+}
+
+func TestListInvitationsWithStatus(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	var ids []string
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		invitation, err := client.CreateInvitation(&ccloud.InvitationCreateReq{Email: email})
+		require.NoError(t, err)
+		ids = append(ids, invitation.Id)
+	}
+	require.NoError(t, srv.AcceptInvitation(ids[1]))
+
+	pending, err := client.ListInvitationsWithStatus(context.Background(), ccloud.InvitationStatusSent)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "a@example.com", pending[0].Email)
+	assert.Equal(t, "c@example.com", pending[1].Email)
+
+	accepted, err := client.ListInvitationsWithStatus(context.Background(), ccloud.InvitationStatusAccepted)
+	require.NoError(t, err)
+	require.Len(t, accepted, 1)
+	assert.True(t, accepted[0].Accepted())
+	assert.NotEmpty(t, accepted[0].AcceptedAt)
+
+	require.NoError(t, client.DeleteInvitation(ids[0]))
+	_, err = client.GetUser(pending[0].User.Id)
+	assert.ErrorIs(t, err, common.ErrNotFound, "revoking a pending invitation removes its user")
+
+	all, err := client.ListAllInvitations(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestDeleteInvitationNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/iam/v2/invitations/i-missing", r.URL.Path)
+
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"status":"404","detail":"not found"}]}`))
+	}))
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	err := client.DeleteInvitation("i-missing")
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	assert.ErrorContains(t, err, "failed to delete invitation")
+}
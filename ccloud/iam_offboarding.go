@@ -0,0 +1,118 @@
+package ccloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+)
+
+// OffboardingPlan is what Offboard removes: the user, its role bindings and
+// the API keys it owns.
+type OffboardingPlan struct {
+	User         *User
+	Scope        crn.CRN
+	RoleBindings []RoleBinding
+	ApiKeys      []ApiKey
+}
+
+// String renders the plan as one line per resource to remove.
+func (p *OffboardingPlan) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "user %s (%s)\n", p.User.Id, p.User.Email)
+	for _, key := range p.ApiKeys {
+		fmt.Fprintf(&sb, "api key %s on %s\n", key.Id, key.Spec.Resource.Id)
+	}
+	for _, rb := range p.RoleBindings {
+		fmt.Fprintf(&sb, "role binding %s: %s on %s\n", rb.Id, rb.RoleName, rb.CrnPattern)
+	}
+	return sb.String()
+}
+
+// PlanOffboarding looks up what Offboard would remove for userId, without
+// changing anything. Only the role bindings at or below scope are listed,
+// usually the organization.
+func (c *ConfluentClient) PlanOffboarding(ctx context.Context, userId string, scope crn.CRN) (*OffboardingPlan, error) {
+	if scope.IsZero() {
+		return nil, fmt.Errorf("failed to plan offboarding of %s: no scope given", userId)
+	}
+
+	user, err := c.GetUserWithContext(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	query := NewListRoleBindingsQuery(scope)
+	query.Principal = UserPrincipal(userId)
+	roleBindings, err := c.ListAllRoleBindings(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys, err := c.ListAllApiKeys(ctx, &ApiKeyListOptions{Owner: userId})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OffboardingPlan{
+		User:         user,
+		Scope:        scope,
+		RoleBindings: roleBindings,
+		ApiKeys:      apiKeys,
+	}, nil
+}
+
+// OffboardingReport holds the plan and the outcome of each removal. UserErr
+// wraps common.ErrBulkSkipped when the user was kept because a key or a
+// binding could not be removed.
+type OffboardingReport struct {
+	OffboardingPlan
+	ApiKeysDeleted      *common.BulkReport[ApiKey, struct{}]
+	RoleBindingsDeleted *common.BulkReport[RoleBinding, struct{}]
+	UserErr             error
+}
+
+func (r *OffboardingReport) Err() error {
+	var errs []error
+	if err := r.ApiKeysDeleted.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete api keys: %w", err))
+	}
+	if err := r.RoleBindingsDeleted.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete role bindings: %w", err))
+	}
+	if r.UserErr != nil && !errors.Is(r.UserErr, common.ErrBulkSkipped) {
+		errs = append(errs, r.UserErr)
+	}
+	return errors.Join(errs...)
+}
+
+// Offboard removes what plan lists: the API keys first, so the credentials
+// stop working, then the role bindings and finally the user. The user is
+// kept when anything else could not be removed, so that Offboard can be
+// retried with a new plan.
+//
+// Resources created after the plan was made are not removed. With
+// client.WithDryRun, every removal is planned and reported as done.
+func (c *ConfluentClient) Offboard(ctx context.Context, plan *OffboardingPlan, opts ...common.BulkOption) *OffboardingReport {
+	report := &OffboardingReport{OffboardingPlan: *plan}
+
+	report.ApiKeysDeleted = common.BulkDo(ctx, plan.ApiKeys, func(ctx context.Context, key ApiKey) error {
+		return planned(c.DeleteApiKeyWithContext(ctx, key.Id))
+	}, opts...)
+
+	report.RoleBindingsDeleted = common.BulkDo(ctx, plan.RoleBindings, func(ctx context.Context, rb RoleBinding) error {
+		return planned(c.DeleteRoleBindingWithContext(ctx, rb.Id))
+	}, opts...)
+
+	if report.ApiKeysDeleted.Err() != nil || report.RoleBindingsDeleted.Err() != nil {
+		report.UserErr = fmt.Errorf("%w: user %s still has api keys or role bindings", common.ErrBulkSkipped, plan.User.Id)
+		return report
+	}
+
+	report.UserErr = planned(c.DeleteUserWithContext(ctx, plan.User.Id))
+
+	return report
+}
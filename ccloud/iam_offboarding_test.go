@@ -0,0 +1,151 @@
+package ccloud_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffboard(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	envId := srv.AddEnvironment("prod")
+	clusterId, err := srv.AddKafkaCluster(envId, "orders")
+	require.NoError(t, err)
+
+	userId := srv.AddUser("jane@example.com", "Jane Doe")
+	otherId := srv.AddUser("john@example.com", "John Doe")
+	org := crn.New(ccloudtest.OrganizationId)
+
+	key, err := client.CreateApiKey(&ccloud.ApiKeyCreateReq{
+		Owner:    ccloud.ApiKeyCommonReq{Id: userId},
+		Resource: ccloud.ApiKeyCommonReq{Id: clusterId, Environment: envId},
+	})
+	require.NoError(t, err)
+	_, err = client.CreateApiKey(&ccloud.ApiKeyCreateReq{Owner: ccloud.ApiKeyCommonReq{Id: otherId}})
+	require.NoError(t, err)
+
+	binding, err := client.CreateRoleBinding(ccloud.NewRoleBindingCreateReq(ccloud.UserPrincipal(userId), "EnvironmentAdmin", org.Environment(envId)))
+	require.NoError(t, err)
+	_, err = client.CreateRoleBinding(ccloud.NewRoleBindingCreateReq(ccloud.UserPrincipal(otherId), "EnvironmentAdmin", org.Environment(envId)))
+	require.NoError(t, err)
+
+	plan, err := client.PlanOffboarding(context.Background(), userId, org)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", plan.User.Email)
+	require.Len(t, plan.ApiKeys, 1)
+	assert.Equal(t, key.Id, plan.ApiKeys[0].Id)
+	require.Len(t, plan.RoleBindings, 1)
+	assert.Equal(t, binding.Id, plan.RoleBindings[0].Id)
+
+	assert.Equal(t, strings.Join([]string{
+		"user " + userId + " (jane@example.com)",
+		"api key " + key.Id + " on " + clusterId,
+		"role binding " + binding.Id + ": EnvironmentAdmin on " + org.Environment(envId).String(),
+		"",
+	}, "\n"), plan.String())
+
+	report := client.Offboard(context.Background(), plan)
+	require.NoError(t, report.Err())
+	assert.Len(t, report.ApiKeysDeleted.Succeeded(), 1)
+	assert.Len(t, report.RoleBindingsDeleted.Succeeded(), 1)
+	assert.NoError(t, report.UserErr)
+
+	_, err = client.GetUser(userId)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+
+	_, err = client.GetUser(otherId)
+	assert.NoError(t, err, "other users are left alone")
+	keys, err := client.ListAllApiKeys(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestOffboardDryRun(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	userId := srv.AddUser("jane@example.com", "Jane Doe")
+	org := crn.New(ccloudtest.OrganizationId)
+	key, err := srv.Client().CreateApiKey(&ccloud.ApiKeyCreateReq{Owner: ccloud.ApiKeyCommonReq{Id: userId}})
+	require.NoError(t, err)
+	binding, err := srv.Client().CreateRoleBinding(ccloud.NewRoleBindingCreateReq(ccloud.UserPrincipal(userId), "MetricsViewer", org))
+	require.NoError(t, err)
+
+	dryRun := client.NewPlan()
+	c := srv.Client(client.WithDryRun(dryRun))
+
+	plan, err := c.PlanOffboarding(context.Background(), userId, org)
+	require.NoError(t, err)
+
+	report := c.Offboard(context.Background(), plan)
+	require.NoError(t, report.Err())
+	assert.NoError(t, report.UserErr)
+
+	assert.Equal(t, strings.Join([]string{
+		"DELETE /iam/v2/api-keys/" + key.Id,
+		"DELETE /iam/v2/role-bindings/" + binding.Id,
+		"DELETE /iam/v2/users/" + userId,
+		"",
+	}, "\n"), dryRun.String())
+
+	_, err = srv.Client().GetUser(userId)
+	assert.NoError(t, err, "nothing was sent")
+}
+
+func TestPlanOffboardingRequiresScope(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	userId := srv.AddUser("jane@example.com", "Jane Doe")
+
+	_, err := srv.Client().PlanOffboarding(context.Background(), userId, crn.CRN{})
+	assert.ErrorContains(t, err, "no scope given")
+}
+
+func TestOffboardKeepsUserWhenDeletionsFail(t *testing.T) {
+	var deletedUser bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/iam/v2/api-keys/"):
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"errors":[{"status":"500","detail":"boom"}]}`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/iam/v2/users/"):
+			deletedUser = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	c := ccloud.NewClient(client.WithRetryMax(0)).WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	plan := &ccloud.OffboardingPlan{
+		User:         &ccloud.User{BaseModel: common.BaseModel{Id: "u-1"}},
+		ApiKeys:      []ccloud.ApiKey{{BaseModel: common.BaseModel{Id: "KEY1"}}},
+		RoleBindings: []ccloud.RoleBinding{{BaseModel: common.BaseModel{Id: "rb-1"}}},
+	}
+
+	report := c.Offboard(context.Background(), plan)
+	assert.Len(t, report.ApiKeysDeleted.Failed(), 1)
+	assert.Len(t, report.RoleBindingsDeleted.Succeeded(), 1)
+	assert.ErrorIs(t, report.UserErr, common.ErrBulkSkipped)
+	assert.False(t, deletedUser)
+
+	err := report.Err()
+	assert.ErrorContains(t, err, "failed to delete api keys")
+	assert.NotErrorIs(t, err, common.ErrBulkSkipped)
+}
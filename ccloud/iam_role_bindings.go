@@ -1,11 +1,11 @@
 package ccloud
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
 )
 
 type RoleBindingCreateReq struct {
@@ -14,6 +14,20 @@ type RoleBindingCreateReq struct {
 	CrnPattern string `json:"crn_pattern"`
 }
 
+// UserPrincipal returns the role binding principal of a user, service
+// account or identity pool id, e.g. "User:pool-abc".
+func UserPrincipal(id string) string {
+	return "User:" + id
+}
+
+func NewRoleBindingCreateReq(principal, roleName string, pattern crn.CRN) *RoleBindingCreateReq {
+	return &RoleBindingCreateReq{Principal: principal, RoleName: roleName, CrnPattern: pattern.String()}
+}
+
+func (r *RoleBindingCreateReq) Crn() (crn.CRN, error) {
+	return crn.Parse(r.CrnPattern)
+}
+
 type RoleBinding struct {
 	common.BaseModel
 	Principal  string `json:"principal"`
@@ -21,6 +35,10 @@ type RoleBinding struct {
 	CrnPattern string `json:"crn_pattern"`
 }
 
+func (r *RoleBinding) Crn() (crn.CRN, error) {
+	return crn.Parse(r.CrnPattern)
+}
+
 type RoleBindingList struct {
 	common.BaseModel
 	Data []RoleBinding `json:"data"`
@@ -33,71 +51,56 @@ type ListRoleBindingsQuery struct {
 	CrnPattern string `url:"crn_pattern,omitempty"`
 }
 
-func (c *ConfluentClient) ListRoleBindings(query *ListRoleBindingsQuery) (*RoleBindingList, error) {
-	urlPath := "/iam/v2/role-bindings"
+// NewListRoleBindingsQuery lists the bindings on scope or below it.
+func NewListRoleBindingsQuery(scope crn.CRN) *ListRoleBindingsQuery {
+	return &ListRoleBindingsQuery{CrnPattern: scope.String()}
+}
 
-	res, err := c.doRequest(urlPath, http.MethodGet, nil, query)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClient) roleBindings() common.Resource[RoleBinding, RoleBindingList] {
+	return common.Resource[RoleBinding, RoleBindingList]{
+		Send: c.send,
+		Api:  "iam",
+		Kind: "RoleBinding",
+		Path: "/iam/v2/role-bindings",
 	}
+}
 
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to list role bindings: %s", res.Status)
-	}
+func (c *ConfluentClient) ListRoleBindings(query *ListRoleBindingsQuery) (*RoleBindingList, error) {
+	return c.ListRoleBindingsWithContext(context.Background(), query)
+}
 
-	defer res.Body.Close()
+func (c *ConfluentClient) ListRoleBindingsWithContext(ctx context.Context, query *ListRoleBindingsQuery) (*RoleBindingList, error) {
+	return c.roleBindings().List(ctx, query)
+}
 
-	var roleBindingList RoleBindingList
-	err = json.NewDecoder(res.Body).Decode(&roleBindingList)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClient) AllRoleBindings(ctx context.Context, opt *ListRoleBindingsQuery) iter.Seq2[RoleBinding, error] {
+	return c.roleBindings().All(ctx, opt)
+}
 
-	return &roleBindingList, nil
+func (c *ConfluentClient) ListAllRoleBindings(ctx context.Context, opt *ListRoleBindingsQuery) ([]RoleBinding, error) {
+	return common.Collect(c.AllRoleBindings(ctx, opt))
 }
 
 func (c *ConfluentClient) GetRoleBinding(roleBindingId string) (*RoleBinding, error) {
-	urlPath := fmt.Sprintf("/iam/v2/role-bindings/%s", roleBindingId)
-
-	res, err := c.doRequest(urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != res.StatusCode {
-		return nil, fmt.Errorf("failed to get role binding: %s", res.Status)
-	}
-
-	defer res.Body.Close()
-
-	var roleBinding RoleBinding
-	err = json.NewDecoder(res.Body).Decode(&roleBinding)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetRoleBindingWithContext(context.Background(), roleBindingId)
+}
 
-	return &roleBinding, nil
+func (c *ConfluentClient) GetRoleBindingWithContext(ctx context.Context, roleBindingId string) (*RoleBinding, error) {
+	return c.roleBindings().Get(ctx, roleBindingId, nil)
 }
 
 func (c *ConfluentClient) CreateRoleBinding(req *RoleBindingCreateReq) (*RoleBinding, error) {
-	urlPath := "/iam/v2/role-bindings"
-
-	res, err := c.doRequest(urlPath, http.MethodPost, req, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusCreated != res.StatusCode {
-		return nil, fmt.Errorf("failed to create role binding: %s", res.Status)
-	}
+	return c.CreateRoleBindingWithContext(context.Background(), req)
+}
 
-	defer res.Body.Close()
+func (c *ConfluentClient) CreateRoleBindingWithContext(ctx context.Context, req *RoleBindingCreateReq) (*RoleBinding, error) {
+	return c.roleBindings().Create(ctx, req, nil)
+}
 
-	var roleBinding RoleBinding
-	err = json.NewDecoder(res.Body).Decode(&roleBinding)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClient) DeleteRoleBinding(roleBindingId string) error {
+	return c.DeleteRoleBindingWithContext(context.Background(), roleBindingId)
+}
 
-	return &roleBinding, nil
+func (c *ConfluentClient) DeleteRoleBindingWithContext(ctx context.Context, roleBindingId string) error {
+	return c.roleBindings().Delete(ctx, roleBindingId, nil)
 }
@@ -0,0 +1,161 @@
+package ccloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+)
+
+type roleBindingKey struct {
+	principal, roleName, crnPattern string
+}
+
+// RoleBindingChanges is the difference between the current and the desired
+// role bindings, see DiffRoleBindings.
+type RoleBindingChanges struct {
+	Create    []*RoleBindingCreateReq
+	Delete    []RoleBinding
+	Unchanged []RoleBinding
+}
+
+// Empty reports whether there is nothing to create or delete.
+func (c *RoleBindingChanges) Empty() bool {
+	return len(c.Create) == 0 && len(c.Delete) == 0
+}
+
+// DiffRoleBindings compares bindings by principal, role name and CRN pattern.
+// Duplicates in desired are created once.
+func DiffRoleBindings(current []RoleBinding, desired []*RoleBindingCreateReq) *RoleBindingChanges {
+	changes := &RoleBindingChanges{}
+
+	wanted := map[roleBindingKey]bool{}
+	for _, req := range desired {
+		wanted[roleBindingKey{req.Principal, req.RoleName, req.CrnPattern}] = true
+	}
+
+	existing := map[roleBindingKey]bool{}
+	for _, rb := range current {
+		key := roleBindingKey{rb.Principal, rb.RoleName, rb.CrnPattern}
+		if wanted[key] && !existing[key] {
+			changes.Unchanged = append(changes.Unchanged, rb)
+		} else {
+			changes.Delete = append(changes.Delete, rb)
+		}
+		existing[key] = true
+	}
+
+	for _, req := range desired {
+		key := roleBindingKey{req.Principal, req.RoleName, req.CrnPattern}
+		if !existing[key] {
+			changes.Create = append(changes.Create, req)
+			existing[key] = true
+		}
+	}
+
+	return changes
+}
+
+// RoleBindingApplyReport holds the planned changes and the outcome of each
+// creation and deletion.
+type RoleBindingApplyReport struct {
+	RoleBindingChanges
+	Created *common.BulkReport[*RoleBindingCreateReq, *RoleBinding]
+	Deleted *common.BulkReport[RoleBinding, struct{}]
+}
+
+func (r *RoleBindingApplyReport) Err() error {
+	var errs []error
+	if err := r.Created.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to create role bindings: %w", err))
+	}
+	if err := r.Deleted.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete role bindings: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// ApplyRoleBindings makes the bindings of every principal in desired, at or
+// below crnPattern, match desired. Principals absent from desired are left
+// untouched; use ReplaceRoleBindings to remove all bindings of a principal.
+//
+// Bindings are created before the others are deleted, so access is not lost
+// in between. Nothing is deleted when a creation fails.
+//
+// With client.WithDryRun, the planned creations count as done, so that the
+// deletions are planned too.
+func (c *ConfluentClient) ApplyRoleBindings(ctx context.Context, crnPattern string, desired []*RoleBindingCreateReq, opts ...common.BulkOption) (*RoleBindingApplyReport, error) {
+	var principals []string
+	for _, req := range desired {
+		if !slices.Contains(principals, req.Principal) {
+			principals = append(principals, req.Principal)
+		}
+	}
+
+	return c.applyRoleBindings(ctx, crnPattern, principals, desired, opts...)
+}
+
+// ReplaceRoleBindings makes the bindings of principal, at or below
+// crnPattern, match desired. An empty desired removes them all.
+func (c *ConfluentClient) ReplaceRoleBindings(ctx context.Context, principal, crnPattern string, desired []*RoleBindingCreateReq, opts ...common.BulkOption) (*RoleBindingApplyReport, error) {
+	for _, req := range desired {
+		if req.Principal != principal {
+			return nil, fmt.Errorf("role binding for %s cannot replace bindings of %s", req.Principal, principal)
+		}
+	}
+
+	return c.applyRoleBindings(ctx, crnPattern, []string{principal}, desired, opts...)
+}
+
+func (c *ConfluentClient) applyRoleBindings(ctx context.Context, crnPattern string, principals []string, desired []*RoleBindingCreateReq, opts ...common.BulkOption) (*RoleBindingApplyReport, error) {
+	scope, err := crn.Parse(crnPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bindings outside of the scope are never listed, so they would be
+	// created again on every apply.
+	for _, req := range desired {
+		pattern, err := req.Crn()
+		if err != nil {
+			return nil, err
+		}
+		if !scope.Contains(pattern) {
+			return nil, fmt.Errorf("role binding on %s is outside of %s", req.CrnPattern, crnPattern)
+		}
+	}
+
+	var current []RoleBinding
+	for _, principal := range principals {
+		bindings, err := c.ListAllRoleBindings(ctx, &ListRoleBindingsQuery{Principal: principal, CrnPattern: crnPattern})
+		if err != nil {
+			return nil, err
+		}
+		current = append(current, bindings...)
+	}
+
+	report := &RoleBindingApplyReport{RoleBindingChanges: *DiffRoleBindings(current, desired)}
+
+	report.Created = c.createRoleBindings(ctx, report.Create, opts...)
+
+	if failed := report.Created.Failed(); len(failed) > 0 {
+		report.Deleted = &common.BulkReport[RoleBinding, struct{}]{}
+		for i, rb := range report.Delete {
+			report.Deleted.Results = append(report.Deleted.Results, common.BulkResult[RoleBinding, struct{}]{
+				Index: i,
+				Item:  rb,
+				Err:   fmt.Errorf("%w: %d role bindings were not created", common.ErrBulkSkipped, len(failed)),
+			})
+		}
+		return report, nil
+	}
+
+	report.Deleted = common.BulkDo(ctx, report.Delete, func(ctx context.Context, rb RoleBinding) error {
+		return planned(c.DeleteRoleBindingWithContext(ctx, rb.Id))
+	}, opts...)
+
+	return report, nil
+}
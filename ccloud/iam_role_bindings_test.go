@@ -1,14 +1,18 @@
 package ccloud_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type noopAuth struct{}
@@ -75,6 +79,7 @@ func TestCreateRoleBindingError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "failed to create role binding")
+	assert.ErrorIs(t, err, common.ErrValidation)
 }
 
 func TestListRoleBindings(t *testing.T) {
@@ -141,9 +146,9 @@ func TestListRoleBindingsError(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+	c := ccloud.NewClient(client.WithRetryMax(0)).WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
 
-	result, err := client.ListRoleBindings(&ccloud.ListRoleBindingsQuery{})
+	result, err := c.ListRoleBindings(&ccloud.ListRoleBindingsQuery{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -197,4 +202,126 @@ func TestGetRoleBindingNotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "failed to get role binding")
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
+
+func TestDiffRoleBindings(t *testing.T) {
+	current := []ccloud.RoleBinding{
+		{BaseModel: common.BaseModel{Id: "rb-1"}, Principal: "User:sa-1", RoleName: "EnvironmentAdmin", CrnPattern: "crn://a"},
+		{BaseModel: common.BaseModel{Id: "rb-2"}, Principal: "User:sa-1", RoleName: "Operator", CrnPattern: "crn://a"},
+	}
+	desired := []*ccloud.RoleBindingCreateReq{
+		{Principal: "User:sa-1", RoleName: "EnvironmentAdmin", CrnPattern: "crn://a"},
+		{Principal: "User:sa-1", RoleName: "DeveloperRead", CrnPattern: "crn://a/topic=*"},
+		{Principal: "User:sa-1", RoleName: "DeveloperRead", CrnPattern: "crn://a/topic=*"},
+	}
+
+	changes := ccloud.DiffRoleBindings(current, desired)
+
+	require.Len(t, changes.Create, 1)
+	assert.Equal(t, "DeveloperRead", changes.Create[0].RoleName)
+	require.Len(t, changes.Delete, 1)
+	assert.Equal(t, "rb-2", changes.Delete[0].Id)
+	require.Len(t, changes.Unchanged, 1)
+	assert.Equal(t, "rb-1", changes.Unchanged[0].Id)
+	assert.False(t, changes.Empty())
+	assert.True(t, ccloud.DiffRoleBindings(current[:1], desired[:1]).Empty())
+}
+
+func TestApplyRoleBindings(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	envId := srv.AddEnvironment("dev")
+	scope := "crn://confluent.cloud/organization=" + ccloudtest.OrganizationId
+	envCrn := scope + "/environment=" + envId
+
+	_, err := client.CreateRoleBinding(&ccloud.RoleBindingCreateReq{Principal: "User:sa-1", RoleName: "Operator", CrnPattern: envCrn})
+	require.NoError(t, err)
+	untouched, err := client.CreateRoleBinding(&ccloud.RoleBindingCreateReq{Principal: "User:sa-2", RoleName: "Operator", CrnPattern: envCrn})
+	require.NoError(t, err)
+
+	desired := []*ccloud.RoleBindingCreateReq{
+		{Principal: "User:sa-1", RoleName: "EnvironmentAdmin", CrnPattern: envCrn},
+	}
+
+	report, err := client.ApplyRoleBindings(ctx, scope, desired)
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+	assert.Len(t, report.Created.Succeeded(), 1)
+	require.Len(t, report.Deleted.Succeeded(), 1)
+	assert.Equal(t, "Operator", report.Deleted.Results[0].Item.RoleName)
+
+	report, err = client.ApplyRoleBindings(ctx, scope, desired)
+	require.NoError(t, err)
+	assert.True(t, report.Empty())
+	assert.Len(t, report.Unchanged, 1)
+
+	_, err = client.ApplyRoleBindings(ctx, envCrn, []*ccloud.RoleBindingCreateReq{{Principal: "User:sa-1", RoleName: "OrganizationAdmin", CrnPattern: scope}})
+	assert.ErrorContains(t, err, "outside of")
+
+	report, err = client.ReplaceRoleBindings(ctx, "User:sa-1", scope, nil)
+	require.NoError(t, err)
+	assert.Len(t, report.Deleted.Succeeded(), 1)
+
+	bindings, err := client.ListAllRoleBindings(ctx, &ccloud.ListRoleBindingsQuery{CrnPattern: scope})
+	require.NoError(t, err)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, untouched.Id, bindings[0].Id)
+}
+
+func TestApplyRoleBindingsKeepsBindingsWhenCreateFails(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	scope := "crn://confluent.cloud/organization=" + ccloudtest.OrganizationId
+	_, err := client.CreateRoleBinding(&ccloud.RoleBindingCreateReq{Principal: "User:sa-1", RoleName: "Operator", CrnPattern: scope})
+	require.NoError(t, err)
+
+	report, err := client.ReplaceRoleBindings(context.Background(), "User:sa-1", scope, []*ccloud.RoleBindingCreateReq{
+		{Principal: "User:sa-1", CrnPattern: scope},
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, report.Err(), common.ErrValidation)
+	require.Len(t, report.Deleted.Skipped(), 1)
+
+	bindings, err := client.ListAllRoleBindings(context.Background(), &ccloud.ListRoleBindingsQuery{CrnPattern: scope})
+	require.NoError(t, err)
+	assert.Len(t, bindings, 1)
+}
+
+func TestApplyRoleBindingsDryRun(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	scope := "crn://confluent.cloud/organization=" + ccloudtest.OrganizationId
+	existing, err := srv.Client().CreateRoleBinding(&ccloud.RoleBindingCreateReq{Principal: "User:sa-1", RoleName: "Operator", CrnPattern: scope})
+	require.NoError(t, err)
+
+	plan := client.NewPlan()
+	c := srv.Client(client.WithDryRun(plan))
+
+	report, err := c.ReplaceRoleBindings(context.Background(), "User:sa-1", scope, []*ccloud.RoleBindingCreateReq{
+		{Principal: "User:sa-1", RoleName: "EnvironmentAdmin", CrnPattern: scope + "/environment=env-1"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+	assert.Len(t, report.Created.Succeeded(), 1)
+	assert.Len(t, report.Deleted.Succeeded(), 1, "planned creations do not skip the deletions")
+
+	ops := plan.Operations()
+	require.Len(t, ops, 2)
+	assert.Equal(t, http.MethodPost, ops[0].Method)
+	assert.Equal(t, "/iam/v2/role-bindings", ops[0].Path)
+	assert.Contains(t, string(ops[0].Body), `"role_name":"EnvironmentAdmin"`)
+	assert.Equal(t, http.MethodDelete, ops[1].Method)
+	assert.Equal(t, "/iam/v2/role-bindings/"+existing.Id, ops[1].Path)
+
+	bindings, err := srv.Client().ListAllRoleBindings(context.Background(), &ccloud.ListRoleBindingsQuery{CrnPattern: scope})
+	require.NoError(t, err)
+	require.Len(t, bindings, 1, "nothing was sent")
+	assert.Equal(t, existing.Id, bindings[0].Id)
 }
@@ -1,9 +1,8 @@
 package ccloud
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
@@ -24,49 +23,37 @@ type ListServiceAccountsQuery struct {
 	DisplayNames []string `url:"display_name,omitempty"`
 }
 
-func (c *ConfluentClient) ListServiceAccounts(query *ListServiceAccountsQuery) (*ServiceAccountList, error) {
-	urlPath := "/iam/v2/service-accounts"
-
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, query)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClient) serviceAccounts() common.Resource[ServiceAccount, ServiceAccountList] {
+	return common.Resource[ServiceAccount, ServiceAccountList]{
+		Send: c.send,
+		Api:  "iam",
+		Kind: "ServiceAccount",
+		Path: "/iam/v2/service-accounts",
 	}
+}
 
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list service-accounts: %s", req.Status)
-	}
+func (c *ConfluentClient) ListServiceAccounts(query *ListServiceAccountsQuery) (*ServiceAccountList, error) {
+	return c.ListServiceAccountsWithContext(context.Background(), query)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClient) ListServiceAccountsWithContext(ctx context.Context, query *ListServiceAccountsQuery) (*ServiceAccountList, error) {
+	return c.serviceAccounts().List(ctx, query)
+}
 
-	var serviceAccounts ServiceAccountList
-	err = json.NewDecoder(req.Body).Decode(&serviceAccounts)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClient) AllServiceAccounts(ctx context.Context, opt *ListServiceAccountsQuery) iter.Seq2[ServiceAccount, error] {
+	return c.serviceAccounts().All(ctx, opt)
+}
 
-	return &serviceAccounts, nil
+func (c *ConfluentClient) ListAllServiceAccounts(ctx context.Context, opt *ListServiceAccountsQuery) ([]ServiceAccount, error) {
+	return common.Collect(c.AllServiceAccounts(ctx, opt))
 }
 
 func (c *ConfluentClient) GetServiceAccount(serviceAccountId string) (*ServiceAccount, error) {
-	urlPath := fmt.Sprintf("/iam/v2/service-accounts/%s", serviceAccountId)
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get service-account: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var serviceAccount ServiceAccount
-	err = json.NewDecoder(req.Body).Decode(&serviceAccount)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetServiceAccountWithContext(context.Background(), serviceAccountId)
+}
 
-	return &serviceAccount, nil
+func (c *ConfluentClient) GetServiceAccountWithContext(ctx context.Context, serviceAccountId string) (*ServiceAccount, error) {
+	return c.serviceAccounts().Get(ctx, serviceAccountId, nil)
 }
 
 type ServiceAccountCreateReq struct {
@@ -75,25 +62,11 @@ type ServiceAccountCreateReq struct {
 }
 
 func (c *ConfluentClient) CreateServiceAccount(create *ServiceAccountCreateReq) (*ServiceAccount, error) {
-	urlPath := "/iam/v2/service-accounts"
-	req, err := c.doRequest(urlPath, http.MethodPost, create, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusCreated != req.StatusCode {
-		return nil, fmt.Errorf("failed to create service account: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var serviceAccount ServiceAccount
-	err = json.NewDecoder(req.Body).Decode(&serviceAccount)
-	if err != nil {
-		return nil, err
-	}
+	return c.CreateServiceAccountWithContext(context.Background(), create)
+}
 
-	return &serviceAccount, nil
+func (c *ConfluentClient) CreateServiceAccountWithContext(ctx context.Context, create *ServiceAccountCreateReq) (*ServiceAccount, error) {
+	return c.serviceAccounts().Create(ctx, create, nil)
 }
 
 type ServiceAccountUpdateReq struct {
@@ -101,37 +74,17 @@ type ServiceAccountUpdateReq struct {
 }
 
 func (c *ConfluentClient) UpdateServiceAccount(serviceAccountId string, update *ServiceAccountUpdateReq) (*ServiceAccount, error) {
-	urlPath := fmt.Sprintf("/iam/v2/service-accounts/%s", serviceAccountId)
-	req, err := c.doRequest(urlPath, http.MethodPatch, update, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get service-account: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var serviceAccount ServiceAccount
-	err = json.NewDecoder(req.Body).Decode(&serviceAccount)
-	if err != nil {
-		return nil, err
-	}
+	return c.UpdateServiceAccountWithContext(context.Background(), serviceAccountId, update)
+}
 
-	return &serviceAccount, nil
+func (c *ConfluentClient) UpdateServiceAccountWithContext(ctx context.Context, serviceAccountId string, update *ServiceAccountUpdateReq) (*ServiceAccount, error) {
+	return c.serviceAccounts().Update(ctx, serviceAccountId, update, nil)
 }
 
 func (c *ConfluentClient) DeleteServiceAccount(serviceAccountId string) error {
-	urlPath := fmt.Sprintf("/iam/v2/service-accounts/%s", serviceAccountId)
-	req, err := c.doRequest(urlPath, http.MethodDelete, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusOK != req.StatusCode && http.StatusNoContent != req.StatusCode {
-		return fmt.Errorf("failed to delete service-account: %s", req.Status)
-	}
+	return c.DeleteServiceAccountWithContext(context.Background(), serviceAccountId)
+}
 
-	return nil
+func (c *ConfluentClient) DeleteServiceAccountWithContext(ctx context.Context, serviceAccountId string) error {
+	return c.serviceAccounts().Delete(ctx, serviceAccountId, nil)
 }
@@ -7,8 +7,10 @@ import (
 	"testing"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type noopAuthSA struct{}
@@ -16,7 +18,7 @@ type noopAuthSA struct{}
 func (n noopAuthSA) SetAuth(req *http.Request) error { return nil }
 
 func TestListServiceAccounts(t *testing.T) {
-	c := makeClient()
+	c := makeClient(t)
 	serviceAccounts, err := c.ListServiceAccounts(&ccloud.ListServiceAccountsQuery{
 		PaginationOptions: common.PaginationOptions{
 			PageSize: 1,
@@ -33,6 +35,25 @@ func TestListServiceAccounts(t *testing.T) {
 
 }
 
+func TestListAndGetServiceAccount(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	saId := srv.AddServiceAccount("tf_runner_sa")
+
+	serviceAccounts, err := c.ListServiceAccounts(&ccloud.ListServiceAccountsQuery{
+		PaginationOptions: common.PaginationOptions{PageSize: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, serviceAccounts.Data, 1)
+	assert.Equal(t, saId, serviceAccounts.Data[0].Id)
+
+	serviceAccount, err := c.GetServiceAccount(saId)
+	require.NoError(t, err)
+	assert.Equal(t, "tf_runner_sa", serviceAccount.DisplayName)
+}
+
 func TestListServiceAccountsWithDisplayName(t *testing.T) {
 	serviceAccountsList := ccloud.ServiceAccountList{
 		Data: []ccloud.ServiceAccount{
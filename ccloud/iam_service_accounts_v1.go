@@ -1,9 +1,10 @@
 package ccloud
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/http"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
 
 type V1ServiceAccount struct {
@@ -22,25 +23,16 @@ type V1QueryOpts struct {
 }
 
 func (c *ConfluentClient) V1ListServiceAccounts(opt *V1QueryOpts) (*V1ServiceAccountList, error) {
-	urlPath := "/service_accounts"
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list service-accounts: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var serviceAccounts V1ServiceAccountList
-	err = json.NewDecoder(req.Body).Decode(&serviceAccounts)
-	if err != nil {
-		return nil, err
-	}
+	return c.V1ListServiceAccountsWithContext(context.Background(), opt)
+}
 
-	return &serviceAccounts, nil
+func (c *ConfluentClient) V1ListServiceAccountsWithContext(ctx context.Context, opt *V1QueryOpts) (*V1ServiceAccountList, error) {
+	return common.Do[V1ServiceAccountList](ctx, c.send, &common.Request{
+		Operation: "iam.V1ListServiceAccounts",
+		Method:    http.MethodGet,
+		Path:      "/service_accounts",
+		Params:    opt,
+	})
 }
 
 func (s *V1ServiceAccount) HasId() bool {
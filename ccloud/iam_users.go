@@ -1,9 +1,8 @@
 package ccloud
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
@@ -19,48 +18,37 @@ type UserList struct {
 	Data []User `json:"data"`
 }
 
-func (c *ConfluentClient) ListUsers(opt *common.PaginationOptions) (*UserList, error) {
-	urlPath := "/iam/v2/users"
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClient) users() common.Resource[User, UserList] {
+	return common.Resource[User, UserList]{
+		Send: c.send,
+		Api:  "iam",
+		Kind: "User",
+		Path: "/iam/v2/users",
 	}
+}
 
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list users: %s", req.Status)
-	}
+func (c *ConfluentClient) ListUsers(opt *common.PaginationOptions) (*UserList, error) {
+	return c.ListUsersWithContext(context.Background(), opt)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClient) ListUsersWithContext(ctx context.Context, opt *common.PaginationOptions) (*UserList, error) {
+	return c.users().List(ctx, opt)
+}
 
-	var userList UserList
-	err = json.NewDecoder(req.Body).Decode(&userList)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClient) AllUsers(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[User, error] {
+	return c.users().All(ctx, opt)
+}
 
-	return &userList, nil
+func (c *ConfluentClient) ListAllUsers(ctx context.Context, opt *common.PaginationOptions) ([]User, error) {
+	return common.Collect(c.AllUsers(ctx, opt))
 }
 
 func (c *ConfluentClient) GetUser(userId string) (*User, error) {
-	urlPath := fmt.Sprintf("/iam/v2/users/%s", userId)
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get user: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var user User
-	err = json.NewDecoder(req.Body).Decode(&user)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetUserWithContext(context.Background(), userId)
+}
 
-	return &user, nil
+func (c *ConfluentClient) GetUserWithContext(ctx context.Context, userId string) (*User, error) {
+	return c.users().Get(ctx, userId, nil)
 }
 
 type UserUpdateReq struct {
@@ -68,37 +56,17 @@ type UserUpdateReq struct {
 }
 
 func (c *ConfluentClient) UpdateUser(userId string, update *UserUpdateReq) (*User, error) {
-	urlPath := fmt.Sprintf("/iam/v2/users/%s", userId)
-	req, err := c.doRequest(urlPath, http.MethodPatch, update, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to update user: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var user User
-	err = json.NewDecoder(req.Body).Decode(&user)
-	if err != nil {
-		return nil, err
-	}
+	return c.UpdateUserWithContext(context.Background(), userId, update)
+}
 
-	return &user, nil
+func (c *ConfluentClient) UpdateUserWithContext(ctx context.Context, userId string, update *UserUpdateReq) (*User, error) {
+	return c.users().Update(ctx, userId, update, nil)
 }
 
 func (c *ConfluentClient) DeleteUser(userId string) error {
-	urlPath := fmt.Sprintf("/iam/v2/users/%s", userId)
-	req, err := c.doRequest(urlPath, http.MethodDelete, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	if http.StatusOK != req.StatusCode && http.StatusNoContent != req.StatusCode {
-		return fmt.Errorf("failed to delete user: %s", req.Status)
-	}
+	return c.DeleteUserWithContext(context.Background(), userId)
+}
 
-	return nil
+func (c *ConfluentClient) DeleteUserWithContext(ctx context.Context, userId string) error {
+	return c.users().Delete(ctx, userId, nil)
 }
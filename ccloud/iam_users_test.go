@@ -1,33 +1,26 @@
 package ccloud_test
 
 import (
-	"fmt"
 	"os"
-
 	"testing"
 
-	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func makeClient() *ccloud.ConfluentClient {
-	key := os.Getenv("CONFLUENT_API_KEY")
-	secret := os.Getenv("CONFLUENT_API_SECRET")
-
-	return ccloud.NewClient().WithAuth(ccloud.NewBasicAuth(key, secret))
-}
-
 func makeRbacCrn() string {
 	schemaRegistryCluster := os.Getenv("SCHEMA_REGISTRY_CLUSTER")
 	organization := os.Getenv("ORGANIZATION")
 	environment := os.Getenv("ENVIRONMENT")
 
-	return fmt.Sprintf("crn://confluent.cloud/organization=%s/environment=%s/schema-registry=%s/subject=*", organization, environment, schemaRegistryCluster)
+	return crn.New(organization).Environment(environment).SchemaRegistry(schemaRegistryCluster).Subject(crn.Wildcard).String()
 }
 
 func TestListRoles(t *testing.T) {
-	c := makeClient()
+	c := makeClient(t)
 	users, err := c.ListUsers(&common.PaginationOptions{
 		PageSize: 1,
 	})
@@ -41,3 +34,20 @@ func TestListRoles(t *testing.T) {
 	assert.NotNil(t, user)
 
 }
+
+func TestListAndGetUser(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	userId := srv.AddUser("jane@example.com", "Jane Doe")
+
+	users, err := c.ListUsers(&common.PaginationOptions{PageSize: 10})
+	require.NoError(t, err)
+	assert.NotEmpty(t, users.Data)
+
+	user, err := c.GetUser(userId)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", user.Email)
+	assert.Equal(t, "Jane Doe", user.FullName)
+}
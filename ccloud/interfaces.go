@@ -0,0 +1,234 @@
+package ccloud
+
+import (
+	"context"
+	"iter"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+)
+
+// The interfaces below group the ConfluentClient methods by domain, so
+// callers can depend on the part of the API they use and substitute fakes in
+// tests.
+
+// EnvironmentsApi manages org/v2 environments.
+type EnvironmentsApi interface {
+	ListEnvironments(opt *common.PaginationOptions) (*EnvironmentList, error)
+	ListEnvironmentsWithContext(ctx context.Context, opt *common.PaginationOptions) (*EnvironmentList, error)
+	AllEnvironments(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[Environment, error]
+	ListAllEnvironments(ctx context.Context, opt *common.PaginationOptions) ([]Environment, error)
+	GetEnvironment(environmentId string) (*Environment, error)
+	GetEnvironmentWithContext(ctx context.Context, environmentId string) (*Environment, error)
+	CreateEnvironment(create *EnvironmentCreateReq) (*ServiceAccount, error)
+	CreateEnvironmentWithContext(ctx context.Context, create *EnvironmentCreateReq) (*Environment, error)
+	UpdateEnvironment(environmentId string, update *EnvironmentUpdateReq) (*Environment, error)
+	UpdateEnvironmentWithContext(ctx context.Context, environmentId string, update *EnvironmentUpdateReq) (*Environment, error)
+	DeleteEnvironment(environmentId string) error
+	DeleteEnvironmentWithContext(ctx context.Context, environmentId string) error
+}
+
+// KafkaClustersApi manages cmk/v2 Kafka clusters.
+type KafkaClustersApi interface {
+	ListKafkaClusters(opt *KafkaClusterListOptions) (*KafkaClusterList, error)
+	ListKafkaClustersWithContext(ctx context.Context, opt *KafkaClusterListOptions) (*KafkaClusterList, error)
+	AllKafkaClusters(ctx context.Context, opt *KafkaClusterListOptions) iter.Seq2[KafkaCluster, error]
+	ListAllKafkaClusters(ctx context.Context, opt *KafkaClusterListOptions) ([]KafkaCluster, error)
+	GetKafkaCluster(kafkaClusterId string, opt *KafkaClusterListOptions) (*KafkaCluster, error)
+	GetKafkaClusterWithContext(ctx context.Context, kafkaClusterId string, opt *KafkaClusterListOptions) (*KafkaCluster, error)
+	CreateKafkaCluster(create *KafkaClusterCreateReq) (*KafkaCluster, error)
+	CreateKafkaClusterWithContext(ctx context.Context, create *KafkaClusterCreateReq) (*KafkaCluster, error)
+	UpdateKafkaCluster(kafkaClusterId string, update *KafkaClusterUpdateReq) (*KafkaCluster, error)
+	UpdateKafkaClusterWithContext(ctx context.Context, kafkaClusterId string, update *KafkaClusterUpdateReq) (*KafkaCluster, error)
+	DeleteKafkaCluster(kafkaClusterId string, opt KafkaClusterListOptions) error
+	DeleteKafkaClusterWithContext(ctx context.Context, kafkaClusterId string, opt KafkaClusterListOptions) error
+}
+
+// SchemaRegistryApi reads srcm/v3 Schema Registry clusters.
+type SchemaRegistryApi interface {
+	ListSchemaRegistry(opt *SchemaRegistryClusterListOptions) (*SchemaRegistryClusterList, error)
+	ListSchemaRegistryWithContext(ctx context.Context, opt *SchemaRegistryClusterListOptions) (*SchemaRegistryClusterList, error)
+	AllSchemaRegistries(ctx context.Context, opt *SchemaRegistryClusterListOptions) iter.Seq2[SchemaRegistryCluster, error]
+	ListAllSchemaRegistries(ctx context.Context, opt *SchemaRegistryClusterListOptions) ([]SchemaRegistryCluster, error)
+	GetSchemaRegistry(schemaRegistryId string, opt *SchemaRegistryClusterListOptions) (*SchemaRegistryCluster, error)
+	GetSchemaRegistryWithContext(ctx context.Context, schemaRegistryId string, opt *SchemaRegistryClusterListOptions) (*SchemaRegistryCluster, error)
+}
+
+// ServiceAccountsApi manages iam/v2 service accounts.
+type ServiceAccountsApi interface {
+	ListServiceAccounts(query *ListServiceAccountsQuery) (*ServiceAccountList, error)
+	ListServiceAccountsWithContext(ctx context.Context, query *ListServiceAccountsQuery) (*ServiceAccountList, error)
+	AllServiceAccounts(ctx context.Context, opt *ListServiceAccountsQuery) iter.Seq2[ServiceAccount, error]
+	ListAllServiceAccounts(ctx context.Context, opt *ListServiceAccountsQuery) ([]ServiceAccount, error)
+	GetServiceAccount(serviceAccountId string) (*ServiceAccount, error)
+	GetServiceAccountWithContext(ctx context.Context, serviceAccountId string) (*ServiceAccount, error)
+	CreateServiceAccount(create *ServiceAccountCreateReq) (*ServiceAccount, error)
+	CreateServiceAccountWithContext(ctx context.Context, create *ServiceAccountCreateReq) (*ServiceAccount, error)
+	UpdateServiceAccount(serviceAccountId string, update *ServiceAccountUpdateReq) (*ServiceAccount, error)
+	UpdateServiceAccountWithContext(ctx context.Context, serviceAccountId string, update *ServiceAccountUpdateReq) (*ServiceAccount, error)
+	DeleteServiceAccount(serviceAccountId string) error
+	DeleteServiceAccountWithContext(ctx context.Context, serviceAccountId string) error
+	V1ListServiceAccounts(opt *V1QueryOpts) (*V1ServiceAccountList, error)
+	V1ListServiceAccountsWithContext(ctx context.Context, opt *V1QueryOpts) (*V1ServiceAccountList, error)
+}
+
+// UsersApi manages iam/v2 users and reads the caller's profile.
+type UsersApi interface {
+	GetMe() (*Profile, error)
+	GetMeWithContext(ctx context.Context) (*Profile, error)
+	ListUsers(opt *common.PaginationOptions) (*UserList, error)
+	ListUsersWithContext(ctx context.Context, opt *common.PaginationOptions) (*UserList, error)
+	AllUsers(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[User, error]
+	ListAllUsers(ctx context.Context, opt *common.PaginationOptions) ([]User, error)
+	GetUser(userId string) (*User, error)
+	GetUserWithContext(ctx context.Context, userId string) (*User, error)
+	UpdateUser(userId string, update *UserUpdateReq) (*User, error)
+	UpdateUserWithContext(ctx context.Context, userId string, update *UserUpdateReq) (*User, error)
+	DeleteUser(userId string) error
+	DeleteUserWithContext(ctx context.Context, userId string) error
+	PlanOffboarding(ctx context.Context, userId string, scope crn.CRN) (*OffboardingPlan, error)
+	Offboard(ctx context.Context, plan *OffboardingPlan, opts ...common.BulkOption) *OffboardingReport
+}
+
+// InvitationsApi manages iam/v2 invitations of new users.
+type InvitationsApi interface {
+	ListInvitations(opt *common.PaginationOptions) (*InvitationList, error)
+	ListInvitationsWithContext(ctx context.Context, opt *common.PaginationOptions) (*InvitationList, error)
+	AllInvitations(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[Invitation, error]
+	ListAllInvitations(ctx context.Context, opt *common.PaginationOptions) ([]Invitation, error)
+	ListInvitationsWithStatus(ctx context.Context, statuses ...string) ([]Invitation, error)
+	GetInvitation(invitationId string) (*Invitation, error)
+	GetInvitationWithContext(ctx context.Context, invitationId string) (*Invitation, error)
+	CreateInvitation(create *InvitationCreateReq) (*Invitation, error)
+	CreateInvitationWithContext(ctx context.Context, create *InvitationCreateReq) (*Invitation, error)
+	DeleteInvitation(invitationId string) error
+	DeleteInvitationWithContext(ctx context.Context, invitationId string) error
+	InviteUser(ctx context.Context, create *InvitationCreateReq, grants []RoleGrant, opts ...common.BulkOption) (*InvitationReport, error)
+}
+
+// ApiKeysApi manages iam/v2 API keys.
+type ApiKeysApi interface {
+	ListApiKeys(opt *ApiKeyListOptions) (*ApiKeyList, error)
+	ListApiKeysWithContext(ctx context.Context, opt *ApiKeyListOptions) (*ApiKeyList, error)
+	AllApiKeys(ctx context.Context, opt *ApiKeyListOptions) iter.Seq2[ApiKey, error]
+	ListAllApiKeys(ctx context.Context, opt *ApiKeyListOptions) ([]ApiKey, error)
+	GetApiKey(apyKeyId string) (*ApiKey, error)
+	GetApiKeyWithContext(ctx context.Context, apyKeyId string) (*ApiKey, error)
+	CreateApiKey(create *ApiKeyCreateReq) (*ApiKey, error)
+	CreateApiKeyWithContext(ctx context.Context, create *ApiKeyCreateReq) (*ApiKey, error)
+	DeleteApiKey(id string) error
+	DeleteApiKeyWithContext(ctx context.Context, id string) error
+	UpdateApiKey(apyKeyId string, update *ApiKeyUpdateReq) (*ApiKey, error)
+	UpdateApiKeyWithContext(ctx context.Context, apyKeyId string, update *ApiKeyUpdateReq) (*ApiKey, error)
+	BulkDeleteApiKeys(ctx context.Context, apiKeyIds []string, opts ...common.BulkOption) *common.BulkReport[string, struct{}]
+}
+
+// RoleBindingsApi manages iam/v2 role bindings.
+type RoleBindingsApi interface {
+	ListRoleBindings(query *ListRoleBindingsQuery) (*RoleBindingList, error)
+	ListRoleBindingsWithContext(ctx context.Context, query *ListRoleBindingsQuery) (*RoleBindingList, error)
+	AllRoleBindings(ctx context.Context, opt *ListRoleBindingsQuery) iter.Seq2[RoleBinding, error]
+	ListAllRoleBindings(ctx context.Context, opt *ListRoleBindingsQuery) ([]RoleBinding, error)
+	GetRoleBinding(roleBindingId string) (*RoleBinding, error)
+	GetRoleBindingWithContext(ctx context.Context, roleBindingId string) (*RoleBinding, error)
+	CreateRoleBinding(req *RoleBindingCreateReq) (*RoleBinding, error)
+	CreateRoleBindingWithContext(ctx context.Context, req *RoleBindingCreateReq) (*RoleBinding, error)
+	DeleteRoleBinding(roleBindingId string) error
+	DeleteRoleBindingWithContext(ctx context.Context, roleBindingId string) error
+	BulkCreateRoleBindings(ctx context.Context, reqs []*RoleBindingCreateReq, opts ...common.BulkOption) *common.BulkReport[*RoleBindingCreateReq, *RoleBinding]
+	ApplyRoleBindings(ctx context.Context, crnPattern string, desired []*RoleBindingCreateReq, opts ...common.BulkOption) (*RoleBindingApplyReport, error)
+	ReplaceRoleBindings(ctx context.Context, principal, crnPattern string, desired []*RoleBindingCreateReq, opts ...common.BulkOption) (*RoleBindingApplyReport, error)
+}
+
+// ConnectorsApi manages connect/v1 connectors.
+type ConnectorsApi interface {
+	CreateConnector(environmentId, clusterId, name string, config interface{}) (*Connector, error)
+	CreateConnectorWithContext(ctx context.Context, environmentId, clusterId, name string, config interface{}) (*Connector, error)
+	ListConnectors(environmentId, clusterId string) ([]Connector, error)
+	ListConnectorsWithContext(ctx context.Context, environmentId, clusterId string) ([]Connector, error)
+	GetConnector(environmentId, clusterId, connectorName string) (*Connector, error)
+	GetConnectorWithContext(ctx context.Context, environmentId, clusterId, connectorName string) (*Connector, error)
+	GetConnectorStatus(environmentId, clusterId, connectorName string) (*ConnectorStatus, error)
+	GetConnectorStatusWithContext(ctx context.Context, environmentId, clusterId, connectorName string) (*ConnectorStatus, error)
+	DeleteConnector(environmentId, clusterId, connectorName string) error
+	DeleteConnectorWithContext(ctx context.Context, environmentId, clusterId, connectorName string) error
+	PauseConnector(environmentId, clusterId, connectorName string) error
+	PauseConnectorWithContext(ctx context.Context, environmentId, clusterId, connectorName string) error
+	ResumeConnector(environmentId, clusterId, connectorName string) error
+	ResumeConnectorWithContext(ctx context.Context, environmentId, clusterId, connectorName string) error
+	RestartConnector(environmentId, clusterId, connectorName string) error
+	RestartConnectorWithContext(ctx context.Context, environmentId, clusterId, connectorName string) error
+	UpdateConnectorConfig(environmentId, clusterId, connectorName string, newConfig interface{}) (*Connector, error)
+	UpdateConnectorConfigWithContext(ctx context.Context, environmentId, clusterId, connectorName string, newConfig interface{}) (*Connector, error)
+	ListConnectorsWithExpansions(environmentId, clusterId string, expand ...string) (map[string]ConnectorWithExpansions, error)
+	ListConnectorsWithExpansionsWithContext(ctx context.Context, environmentId, clusterId string, expand ...string) (map[string]ConnectorWithExpansions, error)
+	GetConnectorWithExpansions(environmentId, clusterId, connectorName string, expand ...string) (*ConnectorWithExpansions, error)
+	GetConnectorWithExpansionsWithContext(ctx context.Context, environmentId, clusterId, connectorName string, expand ...string) (*ConnectorWithExpansions, error)
+}
+
+// ClientQuotasApi manages kafka-quotas/v1 client quotas.
+type ClientQuotasApi interface {
+	ListClientQuotas(opt *ClientQuotaListOptions) (*ClientQuotaList, error)
+	ListClientQuotasWithContext(ctx context.Context, opt *ClientQuotaListOptions) (*ClientQuotaList, error)
+	AllClientQuotas(ctx context.Context, opt *ClientQuotaListOptions) iter.Seq2[ClientQuota, error]
+	ListAllClientQuotas(ctx context.Context, opt *ClientQuotaListOptions) ([]ClientQuota, error)
+	GetClientQuota(id string) (*ClientQuotaDetail, error)
+	GetClientQuotaWithContext(ctx context.Context, id string) (*ClientQuotaDetail, error)
+	CreateClientQuota(create *ClientQuotaCreateReq) (*ClientQuotaDetail, error)
+	CreateClientQuotaWithContext(ctx context.Context, create *ClientQuotaCreateReq) (*ClientQuotaDetail, error)
+	UpdateClientQuota(id string, update *ClientQuotaUpdateReq) (*ClientQuotaDetail, error)
+	UpdateClientQuotaWithContext(ctx context.Context, id string, update *ClientQuotaUpdateReq) (*ClientQuotaDetail, error)
+	DeleteClientQuota(id string) error
+	DeleteClientQuotaWithContext(ctx context.Context, id string) error
+}
+
+// IdentityProvidersApi manages iam/v2 identity providers and their identity
+// pools.
+type IdentityProvidersApi interface {
+	ListIdentityProviders(opt *common.PaginationOptions) (*IdentityProviderList, error)
+	ListIdentityProvidersWithContext(ctx context.Context, opt *common.PaginationOptions) (*IdentityProviderList, error)
+	AllIdentityProviders(ctx context.Context, opt *common.PaginationOptions) iter.Seq2[IdentityProvider, error]
+	ListAllIdentityProviders(ctx context.Context, opt *common.PaginationOptions) ([]IdentityProvider, error)
+	GetIdentityProvider(identityProviderId string) (*IdentityProvider, error)
+	GetIdentityProviderWithContext(ctx context.Context, identityProviderId string) (*IdentityProvider, error)
+	CreateIdentityProvider(create *IdentityProviderCreateReq) (*IdentityProvider, error)
+	CreateIdentityProviderWithContext(ctx context.Context, create *IdentityProviderCreateReq) (*IdentityProvider, error)
+	UpdateIdentityProvider(identityProviderId string, update *IdentityProviderUpdateReq) (*IdentityProvider, error)
+	UpdateIdentityProviderWithContext(ctx context.Context, identityProviderId string, update *IdentityProviderUpdateReq) (*IdentityProvider, error)
+	DeleteIdentityProvider(identityProviderId string) error
+	DeleteIdentityProviderWithContext(ctx context.Context, identityProviderId string) error
+	ListIdentityPools(identityProviderId string, opt *common.PaginationOptions) (*IdentityPoolList, error)
+	ListIdentityPoolsWithContext(ctx context.Context, identityProviderId string, opt *common.PaginationOptions) (*IdentityPoolList, error)
+	AllIdentityPools(ctx context.Context, identityProviderId string, opt *common.PaginationOptions) iter.Seq2[IdentityPool, error]
+	ListAllIdentityPools(ctx context.Context, identityProviderId string, opt *common.PaginationOptions) ([]IdentityPool, error)
+	GetIdentityPool(identityProviderId, identityPoolId string) (*IdentityPool, error)
+	GetIdentityPoolWithContext(ctx context.Context, identityProviderId, identityPoolId string) (*IdentityPool, error)
+	CreateIdentityPool(identityProviderId string, create *IdentityPoolCreateReq) (*IdentityPool, error)
+	CreateIdentityPoolWithContext(ctx context.Context, identityProviderId string, create *IdentityPoolCreateReq) (*IdentityPool, error)
+	UpdateIdentityPool(identityProviderId, identityPoolId string, update *IdentityPoolUpdateReq) (*IdentityPool, error)
+	UpdateIdentityPoolWithContext(ctx context.Context, identityProviderId, identityPoolId string, update *IdentityPoolUpdateReq) (*IdentityPool, error)
+	DeleteIdentityPool(identityProviderId, identityPoolId string) error
+	DeleteIdentityPoolWithContext(ctx context.Context, identityProviderId, identityPoolId string) error
+}
+
+// IamApi groups the iam/v2 domains.
+type IamApi interface {
+	ServiceAccountsApi
+	UsersApi
+	InvitationsApi
+	ApiKeysApi
+	RoleBindingsApi
+	IdentityProvidersApi
+}
+
+// Client is the full Confluent Cloud API implemented by ConfluentClient.
+type Client interface {
+	EnvironmentsApi
+	KafkaClustersApi
+	SchemaRegistryApi
+	IamApi
+	ConnectorsApi
+	ClientQuotasApi
+}
+
+var _ Client = (*ConfluentClient)(nil)
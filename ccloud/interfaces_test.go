@@ -0,0 +1,37 @@
+package ccloud_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeEnvironments struct {
+	ccloud.EnvironmentsApi
+	environments map[string]ccloud.Environment
+}
+
+func (f *fakeEnvironments) GetEnvironmentWithContext(_ context.Context, environmentId string) (*ccloud.Environment, error) {
+	env := f.environments[environmentId]
+	return &env, nil
+}
+
+func environmentName(ctx context.Context, api ccloud.EnvironmentsApi, environmentId string) (string, error) {
+	env, err := api.GetEnvironmentWithContext(ctx, environmentId)
+	if err != nil {
+		return "", err
+	}
+	return env.DisplayName, nil
+}
+
+func TestEnvironmentsApiFake(t *testing.T) {
+	fake := &fakeEnvironments{environments: map[string]ccloud.Environment{
+		"env-1": {DisplayName: "dev"},
+	}}
+
+	name, err := environmentName(context.Background(), fake, "env-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", name)
+}
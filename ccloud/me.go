@@ -1,10 +1,11 @@
 package ccloud
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/http"
 	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
 
 type Profile struct {
@@ -139,23 +140,13 @@ type Profile struct {
 }
 
 func (c *ConfluentClient) GetMe() (*Profile, error) {
+	return c.GetMeWithContext(context.Background())
+}
 
-	req, err := c.doRequest("me", http.MethodGet, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list users: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var profile Profile
-	err = json.NewDecoder(req.Body).Decode(&profile)
-	if err != nil {
-		return nil, err
-	}
-
-	return &profile, nil
+func (c *ConfluentClient) GetMeWithContext(ctx context.Context) (*Profile, error) {
+	return common.Do[Profile](ctx, c.send, &common.Request{
+		Operation: "iam.GetMe",
+		Method:    http.MethodGet,
+		Path:      "me",
+	})
 }
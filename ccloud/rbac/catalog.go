@@ -0,0 +1,147 @@
+package rbac
+
+import (
+	"slices"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+)
+
+type Operation string
+
+const (
+	OperationRead            Operation = "Read"
+	OperationWrite           Operation = "Write"
+	OperationCreate          Operation = "Create"
+	OperationDelete          Operation = "Delete"
+	OperationAlter           Operation = "Alter"
+	OperationDescribe        Operation = "Describe"
+	OperationDescribeConfigs Operation = "DescribeConfigs"
+	OperationAlterConfigs    Operation = "AlterConfigs"
+	OperationIdempotentWrite Operation = "IdempotentWrite"
+	OperationUse             Operation = "Use"
+	// OperationAll grants every operation.
+	OperationAll Operation = "All"
+)
+
+// AnyResource grants a permission on every resource type.
+const AnyResource crn.ResourceType = "*"
+
+// Permission allows operations on the resources of a type within the scope
+// of a binding.
+type Permission struct {
+	ResourceType crn.ResourceType
+	Operations   []Operation
+}
+
+func (p Permission) allows(resourceType crn.ResourceType, op Operation) bool {
+	if p.ResourceType != AnyResource && p.ResourceType != resourceType {
+		return false
+	}
+	return slices.Contains(p.Operations, OperationAll) || slices.Contains(p.Operations, op)
+}
+
+type Role struct {
+	Name        string
+	Permissions []Permission
+}
+
+func (r Role) allows(resourceType crn.ResourceType, op Operation) bool {
+	for _, permission := range r.Permissions {
+		if permission.allows(resourceType, op) {
+			return true
+		}
+	}
+	return false
+}
+
+// Catalog holds roles by name.
+type Catalog map[string]Role
+
+func (c Catalog) Add(role Role) Catalog {
+	c[role.Name] = role
+	return c
+}
+
+// Predefined roles of Confluent Cloud.
+const (
+	RoleOrganizationAdmin = "OrganizationAdmin"
+	RoleEnvironmentAdmin  = "EnvironmentAdmin"
+	RoleCloudClusterAdmin = "CloudClusterAdmin"
+	RoleAccountAdmin      = "AccountAdmin"
+	RoleOperator          = "Operator"
+	RoleMetricsViewer     = "MetricsViewer"
+	RoleResourceOwner     = "ResourceOwner"
+	RoleDeveloperRead     = "DeveloperRead"
+	RoleDeveloperWrite    = "DeveloperWrite"
+	RoleDeveloperManage   = "DeveloperManage"
+	RoleFlinkAdmin        = "FlinkAdmin"
+	RoleFlinkDeveloper    = "FlinkDeveloper"
+)
+
+// DefaultCatalog returns the predefined roles, reduced to the operations
+// that matter on each resource type. It is a model of the documented
+// behavior, not a copy of the server side rules; add or replace roles to
+// match custom needs.
+func DefaultCatalog() Catalog {
+	all := []Operation{OperationAll}
+	describe := []Operation{OperationDescribe, OperationDescribeConfigs}
+
+	return Catalog{}.
+		Add(Role{Name: RoleOrganizationAdmin, Permissions: []Permission{{AnyResource, all}}}).
+		Add(Role{Name: RoleEnvironmentAdmin, Permissions: []Permission{{AnyResource, all}}}).
+		Add(Role{Name: RoleCloudClusterAdmin, Permissions: []Permission{{AnyResource, all}}}).
+		Add(Role{Name: RoleAccountAdmin, Permissions: []Permission{
+			{crn.TypeServiceAccount, all},
+			{crn.TypeUser, all},
+			{crn.TypeIdentityProvider, all},
+			{crn.TypeIdentityPool, all},
+			{crn.TypeGroupMapping, all},
+		}}).
+		Add(Role{Name: RoleOperator, Permissions: []Permission{
+			{AnyResource, describe},
+			{crn.TypeConnector, []Operation{OperationAlter}},
+		}}).
+		Add(Role{Name: RoleMetricsViewer, Permissions: []Permission{
+			{crn.TypeOrganization, []Operation{OperationDescribe}},
+			{crn.TypeEnvironment, []Operation{OperationDescribe}},
+			{crn.TypeCloudCluster, []Operation{OperationDescribe}},
+			{crn.TypeKafka, []Operation{OperationDescribe}},
+		}}).
+		Add(Role{Name: RoleResourceOwner, Permissions: []Permission{
+			{crn.TypeTopic, all},
+			{crn.TypeGroup, all},
+			{crn.TypeTransactionalId, all},
+			{crn.TypeSubject, all},
+			{crn.TypeConnector, all},
+			{crn.TypeKsql, all},
+		}}).
+		Add(Role{Name: RoleDeveloperRead, Permissions: []Permission{
+			{crn.TypeTopic, []Operation{OperationRead, OperationDescribe}},
+			{crn.TypeGroup, []Operation{OperationRead, OperationDescribe}},
+			{crn.TypeSubject, []Operation{OperationRead, OperationDescribe}},
+			{crn.TypeConnector, []Operation{OperationRead, OperationDescribe}},
+			{crn.TypeKsql, []Operation{OperationDescribe}},
+		}}).
+		Add(Role{Name: RoleDeveloperWrite, Permissions: []Permission{
+			{crn.TypeTopic, []Operation{OperationWrite, OperationDescribe}},
+			{crn.TypeTransactionalId, []Operation{OperationWrite, OperationDescribe}},
+			{crn.TypeKafka, []Operation{OperationIdempotentWrite}},
+			{crn.TypeSubject, []Operation{OperationRead, OperationWrite, OperationDescribe}},
+			{crn.TypeKsql, []Operation{OperationDescribe, OperationWrite}},
+		}}).
+		Add(Role{Name: RoleDeveloperManage, Permissions: []Permission{
+			{crn.TypeTopic, []Operation{OperationCreate, OperationDelete, OperationAlter, OperationDescribe, OperationDescribeConfigs, OperationAlterConfigs}},
+			{crn.TypeGroup, []Operation{OperationDelete, OperationDescribe}},
+			{crn.TypeTransactionalId, []Operation{OperationDescribe}},
+			{crn.TypeSubject, []Operation{OperationDelete, OperationDescribe, OperationAlterConfigs}},
+			{crn.TypeConnector, []Operation{OperationCreate, OperationDelete, OperationAlter, OperationDescribe}},
+			{crn.TypeKsql, []Operation{OperationCreate, OperationDelete, OperationDescribe}},
+		}}).
+		Add(Role{Name: RoleFlinkAdmin, Permissions: []Permission{
+			{crn.TypeFlinkRegion, all},
+			{crn.TypeComputePool, all},
+		}}).
+		Add(Role{Name: RoleFlinkDeveloper, Permissions: []Permission{
+			{crn.TypeComputePool, []Operation{OperationDescribe, OperationUse}},
+		}})
+}
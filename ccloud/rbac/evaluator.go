@@ -0,0 +1,148 @@
+// Package rbac answers permission questions offline, from role bindings and
+// a catalog of roles, and explains which binding grants or misses an access.
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+)
+
+// Binding is a role binding with its parsed CRN pattern.
+type Binding struct {
+	Id        string
+	Principal string
+	RoleName  string
+	Pattern   crn.CRN
+}
+
+func (b Binding) String() string {
+	if b.Id == "" {
+		return fmt.Sprintf("%s on %s", b.RoleName, b.Pattern)
+	}
+	return fmt.Sprintf("%s (%s on %s)", b.Id, b.RoleName, b.Pattern)
+}
+
+type Evaluator struct {
+	catalog  Catalog
+	bindings []Binding
+}
+
+// NewEvaluator parses the CRN pattern of every binding, failing on the
+// first invalid one.
+func NewEvaluator(catalog Catalog, roleBindings []ccloud.RoleBinding) (*Evaluator, error) {
+	e := &Evaluator{catalog: catalog}
+
+	for _, rb := range roleBindings {
+		pattern, err := rb.Crn()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse role binding %s: %w", rb.Id, err)
+		}
+		e.bindings = append(e.bindings, Binding{Id: rb.Id, Principal: rb.Principal, RoleName: rb.RoleName, Pattern: pattern})
+	}
+
+	return e, nil
+}
+
+// LoadEvaluator lists the role bindings on scope or below it.
+func LoadEvaluator(ctx context.Context, api ccloud.RoleBindingsApi, catalog Catalog, scope crn.CRN) (*Evaluator, error) {
+	roleBindings, err := api.ListAllRoleBindings(ctx, ccloud.NewListRoleBindingsQuery(scope))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEvaluator(catalog, roleBindings)
+}
+
+// Decision is the answer to a permission query.
+type Decision struct {
+	Principal string
+	Operation Operation
+	Resource  crn.CRN
+	Allowed   bool
+	// Granted lists the bindings allowing the operation.
+	Granted []Binding
+	// Covering lists the bindings of the principal whose scope includes the
+	// resource but whose role does not allow the operation.
+	Covering []Binding
+	// UnknownRoles lists the bindings whose role is not in the catalog.
+	UnknownRoles []Binding
+}
+
+// Explain describes the decision in a sentence per finding.
+func (d *Decision) Explain() string {
+	var b strings.Builder
+
+	if d.Allowed {
+		fmt.Fprintf(&b, "%s can %s %s: granted by %s", d.Principal, d.Operation, d.Resource, d.Granted[0])
+		for _, binding := range d.Granted[1:] {
+			fmt.Fprintf(&b, ", %s", binding)
+		}
+	} else {
+		fmt.Fprintf(&b, "%s cannot %s %s: no role binding grants it", d.Principal, d.Operation, d.Resource)
+		for _, binding := range d.Covering {
+			fmt.Fprintf(&b, "\n%s applies but %s does not allow %s on %s", binding, binding.RoleName, d.Operation, d.Resource.Type())
+		}
+	}
+
+	for _, binding := range d.UnknownRoles {
+		fmt.Fprintf(&b, "\n%s applies but its role is not in the catalog", binding)
+	}
+
+	return b.String()
+}
+
+// Check decides whether principal, e.g. "User:sa-123", may run op on
+// resource. Only bindings whose pattern contains resource apply.
+func (e *Evaluator) Check(principal string, op Operation, resource crn.CRN) *Decision {
+	d := &Decision{Principal: principal, Operation: op, Resource: resource}
+
+	for _, binding := range e.bindings {
+		if binding.Principal != principal || !binding.Pattern.Contains(resource) {
+			continue
+		}
+
+		role, ok := e.catalog[binding.RoleName]
+		switch {
+		case !ok:
+			d.UnknownRoles = append(d.UnknownRoles, binding)
+		case role.allows(resource.Type(), op):
+			d.Granted = append(d.Granted, binding)
+		default:
+			d.Covering = append(d.Covering, binding)
+		}
+	}
+
+	d.Allowed = len(d.Granted) > 0
+
+	return d
+}
+
+// Operations returns the operations principal may run on resource, in the
+// order of the catalog permissions. OperationAll is returned as is.
+func (e *Evaluator) Operations(principal string, resource crn.CRN) []Operation {
+	var ops []Operation
+	seen := map[Operation]bool{}
+
+	for _, binding := range e.bindings {
+		if binding.Principal != principal || !binding.Pattern.Contains(resource) {
+			continue
+		}
+		for _, permission := range e.catalog[binding.RoleName].Permissions {
+			if permission.ResourceType != AnyResource && permission.ResourceType != resource.Type() {
+				continue
+			}
+			for _, op := range permission.Operations {
+				if !seen[op] {
+					seen[op] = true
+					ops = append(ops, op)
+				}
+			}
+		}
+	}
+
+	return ops
+}
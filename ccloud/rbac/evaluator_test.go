@@ -0,0 +1,135 @@
+package rbac_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/electric-saw/ccloud-client-go/ccloud/crn"
+	"github.com/electric-saw/ccloud-client-go/ccloud/rbac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	org     = crn.New("1111aaaa-11aa-11aa-11aa-111111aaaaaa")
+	env     = org.Environment("env-1")
+	cluster = env.KafkaCluster("lkc-abc")
+)
+
+func binding(id, principal, role string, pattern crn.CRN) ccloud.RoleBinding {
+	return ccloud.RoleBinding{
+		BaseModel:  common.BaseModel{Id: id},
+		Principal:  principal,
+		RoleName:   role,
+		CrnPattern: pattern.String(),
+	}
+}
+
+func TestCheck(t *testing.T) {
+	evaluator, err := rbac.NewEvaluator(rbac.DefaultCatalog(), []ccloud.RoleBinding{
+		binding("rb-1", "User:sa-123", rbac.RoleDeveloperWrite, cluster.Topic(crn.Prefix("orders"))),
+		binding("rb-2", "User:sa-123", rbac.RoleDeveloperRead, cluster.Topic(crn.Wildcard)),
+		binding("rb-3", "User:sa-456", rbac.RoleCloudClusterAdmin, env.CloudCluster("lkc-abc")),
+		binding("rb-4", "User:sa-789", "CustomRole", cluster.Topic("orders")),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		principal string
+		op        rbac.Operation
+		resource  crn.CRN
+		allowed   bool
+		granted   []string
+	}{
+		{"prefix grants write", "User:sa-123", rbac.OperationWrite, cluster.Topic("orders-eu"), true, []string{"rb-1"}},
+		{"wildcard grants read", "User:sa-123", rbac.OperationRead, cluster.Topic("payments"), true, []string{"rb-2"}},
+		{"both grant describe", "User:sa-123", rbac.OperationDescribe, cluster.Topic("orders"), true, []string{"rb-1", "rb-2"}},
+		{"outside prefix", "User:sa-123", rbac.OperationWrite, cluster.Topic("payments"), false, nil},
+		{"role lacks operation", "User:sa-123", rbac.OperationDelete, cluster.Topic("orders"), false, nil},
+		{"other cluster", "User:sa-123", rbac.OperationRead, env.KafkaCluster("lkc-other").Topic("orders"), false, nil},
+		{"admin below its scope", "User:sa-456", rbac.OperationDelete, cluster.Group("billing"), true, []string{"rb-3"}},
+		{"unknown principal", "User:sa-000", rbac.OperationRead, cluster.Topic("orders"), false, nil},
+		{"unknown role", "User:sa-789", rbac.OperationRead, cluster.Topic("orders"), false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := evaluator.Check(tt.principal, tt.op, tt.resource)
+			assert.Equal(t, tt.allowed, decision.Allowed)
+
+			var granted []string
+			for _, b := range decision.Granted {
+				granted = append(granted, b.Id)
+			}
+			assert.Equal(t, tt.granted, granted)
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	evaluator, err := rbac.NewEvaluator(rbac.DefaultCatalog(), []ccloud.RoleBinding{
+		binding("rb-1", "User:sa-123", rbac.RoleDeveloperRead, cluster.Topic("orders")),
+		binding("rb-2", "User:sa-123", "CustomRole", cluster.Topic("orders")),
+	})
+	require.NoError(t, err)
+
+	allowed := evaluator.Check("User:sa-123", rbac.OperationRead, cluster.Topic("orders"))
+	assert.Contains(t, allowed.Explain(), "User:sa-123 can Read")
+	assert.Contains(t, allowed.Explain(), "granted by rb-1 (DeveloperRead on "+cluster.Topic("orders").String()+")")
+
+	denied := evaluator.Check("User:sa-123", rbac.OperationWrite, cluster.Topic("orders"))
+	assert.False(t, denied.Allowed)
+	require.Len(t, denied.Covering, 1)
+	assert.Contains(t, denied.Explain(), "no role binding grants it")
+	assert.Contains(t, denied.Explain(), "DeveloperRead does not allow Write on topic")
+	assert.Contains(t, denied.Explain(), "rb-2 (CustomRole on ")
+	assert.Contains(t, denied.Explain(), "its role is not in the catalog")
+}
+
+func TestCustomRolesAndOperations(t *testing.T) {
+	catalog := rbac.DefaultCatalog().Add(rbac.Role{Name: "TopicAuditor", Permissions: []rbac.Permission{
+		{ResourceType: crn.TypeTopic, Operations: []rbac.Operation{rbac.OperationDescribeConfigs}},
+	}})
+
+	evaluator, err := rbac.NewEvaluator(catalog, []ccloud.RoleBinding{
+		binding("rb-1", "User:sa-1", "TopicAuditor", cluster.Topic(crn.Wildcard)),
+		binding("rb-2", "User:sa-1", rbac.RoleDeveloperRead, cluster.Topic("orders")),
+	})
+	require.NoError(t, err)
+
+	assert.True(t, evaluator.Check("User:sa-1", rbac.OperationDescribeConfigs, cluster.Topic("payments")).Allowed)
+	assert.Equal(t,
+		[]rbac.Operation{rbac.OperationDescribeConfigs, rbac.OperationRead, rbac.OperationDescribe},
+		evaluator.Operations("User:sa-1", cluster.Topic("orders")))
+}
+
+func TestNewEvaluatorInvalidCrn(t *testing.T) {
+	_, err := rbac.NewEvaluator(rbac.DefaultCatalog(), []ccloud.RoleBinding{
+		{BaseModel: common.BaseModel{Id: "rb-1"}, Principal: "User:sa-1", RoleName: rbac.RoleDeveloperRead, CrnPattern: "invalid"},
+	})
+	assert.ErrorIs(t, err, crn.ErrInvalid)
+	assert.ErrorContains(t, err, "rb-1")
+}
+
+func TestLoadEvaluator(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	scope := crn.New(ccloudtest.OrganizationId)
+	envId := srv.AddEnvironment("dev")
+	topic := scope.Environment(envId).KafkaCluster("lkc-1").Topic("orders")
+
+	_, err := client.CreateRoleBinding(ccloud.NewRoleBindingCreateReq("User:sa-1", rbac.RoleDeveloperWrite, topic))
+	require.NoError(t, err)
+
+	evaluator, err := rbac.LoadEvaluator(context.Background(), client, rbac.DefaultCatalog(), scope)
+	require.NoError(t, err)
+
+	assert.True(t, evaluator.Check("User:sa-1", rbac.OperationWrite, topic).Allowed)
+	assert.False(t, evaluator.Check("User:sa-1", rbac.OperationRead, topic).Allowed)
+}
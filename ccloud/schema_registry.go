@@ -1,9 +1,8 @@
 package ccloud
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 )
@@ -30,47 +29,36 @@ type SchemaRegistryClusterListOptions struct {
 	EnvironmentId string `url:"environment,omitempty"`
 }
 
-func (c *ConfluentClient) ListSchemaRegistry(opt *SchemaRegistryClusterListOptions) (*SchemaRegistryClusterList, error) {
-	urlPath := "/srcm/v3/clusters"
-
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
+func (c *ConfluentClient) schemaRegistries() common.Resource[SchemaRegistryCluster, SchemaRegistryClusterList] {
+	return common.Resource[SchemaRegistryCluster, SchemaRegistryClusterList]{
+		Send:   c.send,
+		Api:    "srcm",
+		Kind:   "SchemaRegistry",
+		Plural: "SchemaRegistry",
+		Path:   "/srcm/v3/clusters",
 	}
+}
 
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to list kafka clusters: %s", req.Status)
-	}
+func (c *ConfluentClient) ListSchemaRegistry(opt *SchemaRegistryClusterListOptions) (*SchemaRegistryClusterList, error) {
+	return c.ListSchemaRegistryWithContext(context.Background(), opt)
+}
 
-	defer req.Body.Close()
+func (c *ConfluentClient) ListSchemaRegistryWithContext(ctx context.Context, opt *SchemaRegistryClusterListOptions) (*SchemaRegistryClusterList, error) {
+	return c.schemaRegistries().List(ctx, opt)
+}
 
-	var schemaRegistryClusters SchemaRegistryClusterList
-	err = json.NewDecoder(req.Body).Decode(&schemaRegistryClusters)
-	if err != nil {
-		return nil, err
-	}
+func (c *ConfluentClient) AllSchemaRegistries(ctx context.Context, opt *SchemaRegistryClusterListOptions) iter.Seq2[SchemaRegistryCluster, error] {
+	return c.schemaRegistries().All(ctx, opt)
+}
 
-	return &schemaRegistryClusters, nil
+func (c *ConfluentClient) ListAllSchemaRegistries(ctx context.Context, opt *SchemaRegistryClusterListOptions) ([]SchemaRegistryCluster, error) {
+	return common.Collect(c.AllSchemaRegistries(ctx, opt))
 }
 
 func (c *ConfluentClient) GetSchemaRegistry(schemaRegistryId string, opt *SchemaRegistryClusterListOptions) (*SchemaRegistryCluster, error) {
-	urlPath := fmt.Sprintf("/srcm/v3/clusters/%s", schemaRegistryId)
-	req, err := c.doRequest(urlPath, http.MethodGet, nil, opt)
-	if err != nil {
-		return nil, err
-	}
-
-	if http.StatusOK != req.StatusCode {
-		return nil, fmt.Errorf("failed to get kafka cluster: %s", req.Status)
-	}
-
-	defer req.Body.Close()
-
-	var schemaRegistryCluster SchemaRegistryCluster
-	err = json.NewDecoder(req.Body).Decode(&schemaRegistryCluster)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetSchemaRegistryWithContext(context.Background(), schemaRegistryId, opt)
+}
 
-	return &schemaRegistryCluster, nil
+func (c *ConfluentClient) GetSchemaRegistryWithContext(ctx context.Context, schemaRegistryId string, opt *SchemaRegistryClusterListOptions) (*SchemaRegistryCluster, error) {
+	return c.schemaRegistries().Get(ctx, schemaRegistryId, opt)
 }
@@ -1,15 +1,19 @@
 package ccloud_test
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/electric-saw/ccloud-client-go/ccloud"
 	"github.com/electric-saw/ccloud-client-go/ccloud/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListSr(t *testing.T) {
-	c := makeClient()
+	c := makeClient(t)
 
 	environments, err := c.ListEnvironments(&common.PaginationOptions{
 		PageSize: 1})
@@ -34,3 +38,33 @@ func TestListSr(t *testing.T) {
 	assert.NotNil(t, cluster)
 
 }
+
+func TestListAndGetSchemaRegistry(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "env-1", r.URL.Query().Get("environment"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/srcm/v3/clusters":
+			fmt.Fprint(w, `{"data":[{"id":"lsrc-1","spec":{"display_name":"Stream Governance","environment":{"id":"env-1"}}}],"metadata":{}}`)
+		case "/srcm/v3/clusters/lsrc-1":
+			fmt.Fprint(w, `{"id":"lsrc-1","spec":{"display_name":"Stream Governance","http_endpoint":"https://psrc-1.confluent.cloud","environment":{"id":"env-1"}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := ccloud.NewClient().WithAuth(noopAuth{}).WithBaseUrl(ts.URL)
+
+	clusters, err := client.ListSchemaRegistry(&ccloud.SchemaRegistryClusterListOptions{EnvironmentId: "env-1"})
+	require.NoError(t, err)
+	require.Len(t, clusters.Data, 1)
+
+	cluster, err := client.GetSchemaRegistry(clusters.Data[0].Id, &ccloud.SchemaRegistryClusterListOptions{
+		EnvironmentId: clusters.Data[0].Spec.Environment.Id,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://psrc-1.confluent.cloud", cluster.Spec.Endpoint)
+}
@@ -0,0 +1,175 @@
+package ccloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+)
+
+// Phases reported in Status.Phase of Kafka clusters.
+const (
+	KafkaClusterPhaseProvisioning   = "PROVISIONING"
+	KafkaClusterPhaseProvisioned    = "PROVISIONED"
+	KafkaClusterPhaseFailed         = "FAILED"
+	KafkaClusterPhaseDeprovisioning = "DEPROVISIONING"
+)
+
+// States reported by GetConnectorStatus for connectors and their tasks.
+const (
+	ConnectorStateProvisioning = "PROVISIONING"
+	ConnectorStateRunning      = "RUNNING"
+	ConnectorStatePaused       = "PAUSED"
+	ConnectorStateFailed       = "FAILED"
+)
+
+// States reported to the progress callback of WaitForApiKeyActive.
+const (
+	ApiKeyStateNotFound     = "NOT_FOUND"
+	ApiKeyStateUnauthorized = "UNAUTHORIZED"
+	ApiKeyStateActive       = "ACTIVE"
+)
+
+// WaitForKafkaClusterPhase polls a cluster until it reaches phase. A FAILED
+// cluster ends the wait with a *common.WaitError wrapping common.ErrWaitFailed,
+// unless FAILED is the phase waited for.
+func (c *ConfluentClient) WaitForKafkaClusterPhase(ctx context.Context, kafkaClusterId string, opt *KafkaClusterListOptions, phase string, opts ...common.WaitOption) (*KafkaCluster, error) {
+	resource := fmt.Sprintf("kafka cluster %s", kafkaClusterId)
+
+	return common.Wait(ctx, resource, phase, func(ctx context.Context) (common.Observation[*KafkaCluster], error) {
+		kafkaCluster, err := c.GetKafkaClusterWithContext(ctx, kafkaClusterId, opt)
+		if err != nil {
+			return common.Observation[*KafkaCluster]{}, err
+		}
+
+		return common.Observation[*KafkaCluster]{
+			Value:  kafkaCluster,
+			State:  kafkaCluster.Status.Phase,
+			Done:   kafkaCluster.Status.Phase == phase,
+			Failed: kafkaCluster.Status.Phase == KafkaClusterPhaseFailed,
+		}, nil
+	}, opts...)
+}
+
+// WaitForConnectorRunning polls the status of a connector until it and all
+// of its tasks are RUNNING. A failed connector or task ends the wait with a
+// *common.WaitError carrying its trace.
+func (c *ConfluentClient) WaitForConnectorRunning(ctx context.Context, environmentId, clusterId, connectorName string, opts ...common.WaitOption) (*ConnectorStatus, error) {
+	resource := fmt.Sprintf("connector %s", connectorName)
+
+	return common.Wait(ctx, resource, ConnectorStateRunning, func(ctx context.Context) (common.Observation[*ConnectorStatus], error) {
+		status, err := c.GetConnectorStatusWithContext(ctx, environmentId, clusterId, connectorName)
+		if err != nil {
+			return common.Observation[*ConnectorStatus]{}, err
+		}
+
+		return connectorObservation(status), nil
+	}, opts...)
+}
+
+func connectorObservation(status *ConnectorStatus) common.Observation[*ConnectorStatus] {
+	observation := common.Observation[*ConnectorStatus]{
+		Value:  status,
+		State:  status.Connector.State,
+		Detail: status.Connector.Trace,
+		Failed: status.Connector.State == ConnectorStateFailed,
+	}
+
+	running := 0
+	for _, task := range status.Tasks {
+		switch task.State {
+		case ConnectorStateRunning:
+			running++
+		case ConnectorStateFailed:
+			observation.Failed = true
+			if observation.Detail == "" {
+				observation.Detail = fmt.Sprintf("task %d: %s", task.Id, task.Trace)
+			}
+		}
+	}
+
+	// A connector reports RUNNING before its tasks are started.
+	if status.Connector.State == ConnectorStateRunning && running < len(status.Tasks) {
+		observation.State = ConnectorStateProvisioning
+	}
+
+	observation.Done = !observation.Failed && status.Connector.State == ConnectorStateRunning &&
+		len(status.Tasks) > 0 && running == len(status.Tasks)
+
+	return observation
+}
+
+// WaitForApiKeyActive polls until a newly created key is listed and accepted
+// by the resource it belongs to: the cloud API, or kafkaCluster for a
+// cluster scoped key. The key must carry the secret returned by CreateApiKey.
+func (c *ConfluentClient) WaitForApiKeyActive(ctx context.Context, key *ApiKey, kafkaCluster *KafkaCluster, opts ...common.WaitOption) error {
+	if key == nil || key.Spec.Secret == "" {
+		return fmt.Errorf("api key with its secret is required")
+	}
+
+	auth := client.NewBasicAuth(key.Id, key.Spec.Secret)
+
+	var probe func(ctx context.Context) error
+	if kafkaCluster != nil {
+		clusterClient, err := c.NewClusterClient(kafkaCluster, auth, probeOptions(c.http.Options()))
+		if err != nil {
+			return err
+		}
+		probe = clusterClient.Discover
+	} else {
+		cloudClient := NewClient(probeOptions(c.http.Options())).WithBaseUrl(c.BaseUrl).WithAuth(auth)
+		probe = func(ctx context.Context) error {
+			_, err := cloudClient.ListEnvironmentsWithContext(ctx, &common.PaginationOptions{PageSize: 1})
+			return err
+		}
+	}
+
+	resource := fmt.Sprintf("api key %s", key.Id)
+
+	_, err := common.Wait(ctx, resource, ApiKeyStateActive, func(ctx context.Context) (common.Observation[*ApiKey], error) {
+		current, err := c.GetApiKeyWithContext(ctx, key.Id)
+		if errors.Is(err, common.ErrNotFound) {
+			return common.Observation[*ApiKey]{Value: key, State: ApiKeyStateNotFound}, nil
+		}
+		if err != nil {
+			return common.Observation[*ApiKey]{}, err
+		}
+
+		// Any answer but 401, a 403 included, means the key was recognized.
+		err = probe(ctx)
+		if errors.Is(err, common.ErrUnauthorized) {
+			return common.Observation[*ApiKey]{Value: current, State: ApiKeyStateUnauthorized}, nil
+		}
+		var apiErr *common.APIError
+		if err != nil && !errors.As(err, &apiErr) {
+			return common.Observation[*ApiKey]{}, err
+		}
+
+		return common.Observation[*ApiKey]{Value: current, State: ApiKeyStateActive, Done: true}, nil
+	}, opts...)
+
+	return err
+}
+
+// probeOptions keeps only how base reaches the API. The probe authenticates
+// with another key and is not a call of the user, so it must not go through
+// the cache, dry-run plan, rate limiter, middlewares or observers of base.
+func probeOptions(base client.Options) client.Option {
+	return func(o *client.Options) {
+		*o = client.Options{
+			HttpClient:  base.HttpClient,
+			Transport:   base.Transport,
+			RetryMax:    base.RetryMax,
+			Timeout:     base.Timeout,
+			UserAgent:   base.UserAgent,
+			Headers:     base.Headers.Clone(),
+			RetryPolicy: base.RetryPolicy,
+		}
+		if o.Headers == nil {
+			o.Headers = http.Header{}
+		}
+	}
+}
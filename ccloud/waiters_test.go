@@ -0,0 +1,149 @@
+package ccloud_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/electric-saw/ccloud-client-go/ccloud"
+	"github.com/electric-saw/ccloud-client-go/ccloud/ccloudtest"
+	"github.com/electric-saw/ccloud-client-go/ccloud/client"
+	"github.com/electric-saw/ccloud-client-go/ccloud/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fastWait = common.WithWaitInterval(time.Millisecond, 5*time.Millisecond)
+
+func TestWaitForKafkaClusterPhase(t *testing.T) {
+	srv := ccloudtest.NewServer(ccloudtest.WithProvisioningPolls(2))
+	defer srv.Close()
+
+	c := srv.Client()
+	envId := srv.AddEnvironment("dev")
+
+	req := &ccloud.KafkaClusterCreateReq{DisplayName: "orders", Cloud: common.CloudProviderAWS, Region: "us-east-1"}
+	req.Config.Kind = ccloud.KafkaClusterKindBasic
+	req.Environment.Id = envId
+
+	created, err := c.CreateKafkaCluster(req)
+	require.NoError(t, err)
+
+	var phases []string
+	kafkaCluster, err := c.WaitForKafkaClusterPhase(context.Background(), created.Id, &ccloud.KafkaClusterListOptions{EnvironmentId: envId}, ccloud.KafkaClusterPhaseProvisioned,
+		fastWait,
+		common.WithWaitProgress(func(p common.WaitProgress) { phases = append(phases, p.State) }),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, ccloud.KafkaClusterPhaseProvisioned, kafkaCluster.Status.Phase)
+	assert.Equal(t, []string{"PROVISIONING", "PROVISIONING", "PROVISIONED"}, phases)
+
+	require.NoError(t, srv.SetKafkaClusterPhase(created.Id, ccloudtest.PhaseFailed))
+	_, err = c.WaitForKafkaClusterPhase(context.Background(), created.Id, &ccloud.KafkaClusterListOptions{EnvironmentId: envId}, ccloud.KafkaClusterPhaseProvisioned, fastWait)
+	assert.ErrorIs(t, err, common.ErrWaitFailed)
+}
+
+func TestWaitForConnectorRunning(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	c := srv.Client()
+	envId := srv.AddEnvironment("dev")
+	clusterId, err := srv.AddKafkaCluster(envId, "orders")
+	require.NoError(t, err)
+
+	config := map[string]string{"connector.class": "DatagenSource", "tasks.max": "2"}
+	_, err = c.CreateConnector(envId, clusterId, "datagen", config)
+	require.NoError(t, err)
+
+	status, err := c.WaitForConnectorRunning(context.Background(), envId, clusterId, "datagen", fastWait)
+	require.NoError(t, err)
+	assert.Len(t, status.Tasks, 2)
+
+	require.NoError(t, srv.SetConnectorState(envId, clusterId, "datagen", ccloudtest.ConnectorStateFailed, "java.lang.NullPointerException"))
+
+	_, err = c.WaitForConnectorRunning(context.Background(), envId, clusterId, "datagen", fastWait)
+	assert.ErrorIs(t, err, common.ErrWaitFailed)
+	assert.ErrorContains(t, err, "java.lang.NullPointerException")
+
+	var waitErr *common.WaitError
+	require.True(t, errors.As(err, &waitErr))
+	assert.Equal(t, ccloud.ConnectorStateFailed, waitErr.Last.(*ccloud.ConnectorStatus).Connector.State)
+}
+
+func TestWaitForApiKeyActive(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	c := srv.Client()
+	envId := srv.AddEnvironment("dev")
+	clusterId, err := srv.AddKafkaCluster(envId, "orders")
+	require.NoError(t, err)
+	saId := srv.AddServiceAccount("orders-app")
+
+	cloudKey, err := c.CreateApiKey(&ccloud.ApiKeyCreateReq{Owner: ccloud.ApiKeyCommonReq{Id: saId}})
+	require.NoError(t, err)
+	assert.NoError(t, c.WaitForApiKeyActive(context.Background(), cloudKey, nil, fastWait))
+
+	kafkaCluster, err := c.GetKafkaCluster(clusterId, &ccloud.KafkaClusterListOptions{EnvironmentId: envId})
+	require.NoError(t, err)
+
+	clusterKey, err := c.CreateApiKey(&ccloud.ApiKeyCreateReq{
+		Owner:    ccloud.ApiKeyCommonReq{Id: saId},
+		Resource: ccloud.ApiKeyCommonReq{Id: clusterId, Environment: envId},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, c.WaitForApiKeyActive(context.Background(), clusterKey, kafkaCluster, fastWait))
+
+	missing := &ccloud.ApiKey{Spec: ccloud.ApiKeySpec{Secret: "secret"}}
+	missing.Id = "MISSINGKEY"
+	err = c.WaitForApiKeyActive(context.Background(), missing, nil, fastWait, common.WithWaitTimeout(20*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, ccloud.ApiKeyStateNotFound)
+}
+
+func TestWaitForApiKeyActiveProbeBypassesClientOptions(t *testing.T) {
+	srv := ccloudtest.NewServer()
+	defer srv.Close()
+
+	var mu sync.Mutex
+	var seen []string
+	record := func(next client.Handler) client.Handler {
+		return func(ctx context.Context, r *client.Request) (*http.Response, error) {
+			mu.Lock()
+			seen = append(seen, r.Method+" "+r.Path())
+			mu.Unlock()
+			return next(ctx, r)
+		}
+	}
+
+	envId := srv.AddEnvironment("dev")
+	clusterId, err := srv.AddKafkaCluster(envId, "orders")
+	require.NoError(t, err)
+	saId := srv.AddServiceAccount("orders-app")
+
+	kafkaCluster, err := srv.Client().GetKafkaCluster(clusterId, &ccloud.KafkaClusterListOptions{EnvironmentId: envId})
+	require.NoError(t, err)
+	cloudKey, err := srv.Client().CreateApiKey(&ccloud.ApiKeyCreateReq{Owner: ccloud.ApiKeyCommonReq{Id: saId}})
+	require.NoError(t, err)
+	clusterKey, err := srv.Client().CreateApiKey(&ccloud.ApiKeyCreateReq{
+		Owner:    ccloud.ApiKeyCommonReq{Id: saId},
+		Resource: ccloud.ApiKeyCommonReq{Id: clusterId, Environment: envId},
+	})
+	require.NoError(t, err)
+
+	plan := client.NewPlan()
+	c := srv.Client(client.WithMiddleware(record), client.WithDryRun(plan))
+
+	require.NoError(t, c.WaitForApiKeyActive(context.Background(), cloudKey, nil, fastWait))
+	require.NoError(t, c.WaitForApiKeyActive(context.Background(), clusterKey, kafkaCluster, fastWait))
+
+	assert.Equal(t, 0, plan.Len())
+	for _, call := range seen {
+		assert.Contains(t, call, "/iam/v2/api-keys/", "the probes are not calls of the user")
+	}
+	assert.NotEmpty(t, seen)
+}